@@ -1,18 +1,41 @@
 package main
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"strings"
 
 	"github.com/markgx/gofeedfinder/pkg/gofeedfinder"
 )
 
 var version = "dev"
 
+// Exit codes, so scripts can tell a page that failed to load from one without feeds.
+const (
+	exitUsage      = 1 // Bad arguments, unreadable input file, or an output error
+	exitFetchError = 2 // The page could not be fetched or read
+	exitNoFeeds    = 3 // The page loaded but has no feeds
+)
+
 func main() {
 	withAttributes := flag.Bool("with-attributes", false, "Display additional feed attributes")
 	scanCommonPaths := flag.Bool("scan-common-paths", false, "Scan common feed paths when no feeds found in HTML")
+	var scanPaths pathList
+	flag.Var(&scanPaths, "scan-paths", "Extra comma-separated paths to scan, e.g. /custom,/another (implies --scan-common-paths)")
+	siteSpecific := flag.Bool("site-specific", false, "Check known sites (e.g. Reddit) for predictable feed URLs")
+	jsonOutput := flag.Bool("json", false, "Output feeds as JSON (ignores --with-attributes)")
+	opmlOutput := flag.Bool("opml", false, "Output feeds as an OPML 2.0 document (ignores --with-attributes)")
+	csvOutput := flag.Bool("csv", false, "Output feeds as CSV with url, title, and type columns (ignores --with-attributes)")
+	sortBy := flag.String("sort", gofeedfinder.SortNone, "Sort each URL's feeds by url, type, or title (none keeps discovery order)")
+	inputFile := flag.String("input-file", "", "Read URLs from a file, one per line (- for stdin)")
+	verbose := flag.Bool("verbose", false, "Log requests and discovery steps to stderr")
+	listPaths := flag.Bool("list-paths", false, "List the built-in common feed paths and exit")
 	showVersion := flag.Bool("version", false, "Show version information")
 	flag.Parse()
 
@@ -21,30 +44,218 @@ func main() {
 		os.Exit(0)
 	}
 
-	if len(flag.Args()) < 1 {
-		fmt.Println("Usage: gofeedfinder [--with-attributes] [--scan-common-paths] [--version] <url>")
-		os.Exit(1)
+	if *listPaths {
+		for _, path := range gofeedfinder.CommonFeedPaths() {
+			fmt.Println(path)
+		}
+		os.Exit(0)
+	}
+
+	urls := flag.Args()
+	if *inputFile != "" {
+		fileURLs, err := readURLFile(*inputFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(exitUsage)
+		}
+		urls = append(urls, fileURLs...)
+	}
+
+	if len(urls) < 1 {
+		fmt.Println("Usage: gofeedfinder [--with-attributes] [--scan-common-paths] [--scan-paths <paths>] [--site-specific] [--json | --opml | --csv] [--sort <key>] [--input-file <path>] [--verbose] [--version] <url> [<url>...]")
+		os.Exit(exitUsage)
+	}
+
+	formats := 0
+	for _, set := range []bool{*jsonOutput, *opmlOutput, *csvOutput} {
+		if set {
+			formats++
+		}
+	}
+	if formats > 1 {
+		fmt.Fprintln(os.Stderr, "Error: only one of --json, --opml, and --csv can be used")
+		os.Exit(exitUsage)
+	}
+
+	switch *sortBy {
+	case gofeedfinder.SortNone, gofeedfinder.SortURL, gofeedfinder.SortType, gofeedfinder.SortTitle:
+	default:
+		fmt.Fprintf(os.Stderr, "Error: --sort must be none, url, type, or title, not %q\n", *sortBy)
+		os.Exit(exitUsage)
+	}
+
+	opts := gofeedfinder.DefaultOptions()
+	opts.SortBy = *sortBy
+	opts.ScanCommonPaths = *scanCommonPaths || len(scanPaths) > 0
+	opts.CustomFeedPaths = scanPaths
+	opts.EnableSiteSpecific = *siteSpecific
+	if *verbose {
+		opts.Logger = log.New(os.Stderr, "gofeedfinder: ", 0)
+	}
+
+	results, errs := gofeedfinder.FindFeedsForURLs(urls, opts)
+	var failures []error
+	for i, url := range urls {
+		if err, ok := errs[url]; ok {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", url, err)
+			failures = append(failures, err)
+			continue
+		}
+
+		if formats > 0 {
+			continue
+		}
+
+		if len(urls) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("%s:\n", url)
+		}
+		printFeeds(results[url], *withAttributes)
+	}
+
+	if *jsonOutput {
+		// A single URL keeps the plain array output; several URLs are keyed by URL.
+		var err error
+		if len(urls) == 1 {
+			err = gofeedfinder.WriteJSON(os.Stdout, results[urls[0]])
+		} else {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			err = enc.Encode(results)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if *opmlOutput {
+		// The document title is the source page for a single URL.
+		title := "gofeedfinder"
+		if len(urls) == 1 {
+			title = urls[0]
+		}
+		var feeds []gofeedfinder.Feed
+		for _, url := range urls {
+			feeds = append(feeds, results[url]...)
+		}
+		if err := gofeedfinder.WriteOPML(os.Stdout, feeds, title); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if *csvOutput {
+		var feeds []gofeedfinder.Feed
+		for _, url := range urls {
+			feeds = append(feeds, results[url]...)
+		}
+		if err := gofeedfinder.WriteCSV(os.Stdout, feeds); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if len(failures) == len(urls) {
+		os.Exit(failuresExitCode(failures))
+	}
+}
+
+// exitCode maps a discovery error to the exit code reported for it.
+func exitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, gofeedfinder.ErrNoFeedsFound):
+		return exitNoFeeds
+	case errors.Is(err, gofeedfinder.ErrInvalidURL):
+		return exitUsage
+	default:
+		return exitFetchError
 	}
+}
 
-	url := flag.Args()[0]
+// failuresExitCode returns the exit code when every URL failed, with errs. A fetch error
+// on any URL takes precedence over an invalid URL, which takes precedence over no feeds.
+func failuresExitCode(errs []error) int {
+	code := exitNoFeeds
+	for _, err := range errs {
+		switch c := exitCode(err); {
+		case c == exitFetchError:
+			return exitFetchError
+		case c == exitUsage:
+			code = exitUsage
+		}
+	}
+	return code
+}
 
-	opts := gofeedfinder.Options{
-		ScanCommonPaths: *scanCommonPaths,
-		MaxConcurrency:  3,
+// readURLFile reads URLs from the file at path, or from stdin if path is "-".
+func readURLFile(path string) ([]string, error) {
+	if path == "-" {
+		return readURLs(os.Stdin)
 	}
-	feeds, err := gofeedfinder.FindFeedsWithOptions(url, opts)
+
+	f, err := os.Open(path)
 	if err != nil {
-		fmt.Println("Error:", err)
-		os.Exit(1)
+		return nil, err
+	}
+	defer f.Close()
+	return readURLs(f)
+}
+
+// readURLs reads one URL per line from r, skipping blank lines and # comments.
+func readURLs(r io.Reader) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	return urls, scanner.Err()
+}
+
+// pathList is a flag.Value collecting paths from a flag that may be repeated and
+// may hold several comma-separated paths.
+type pathList []string
+
+func (p *pathList) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *pathList) Set(value string) error {
+	for _, path := range strings.Split(value, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			*p = append(*p, path)
+		}
 	}
+	return nil
+}
 
+// printFeeds writes feeds to stdout, one per line.
+func printFeeds(feeds []gofeedfinder.Feed, withAttributes bool) {
 	for _, feed := range feeds {
-		if *withAttributes {
+		if withAttributes {
 			fmt.Printf("%s", feed.URL)
 			if feed.Title != "" {
 				fmt.Printf(" title=%s", feed.Title)
 			}
-			fmt.Printf(" type=%s\n", feed.Type)
+			fmt.Printf(" type=%s", feed.Type)
+			if feed.Kind != "" {
+				fmt.Printf(" kind=%s", feed.Kind)
+			}
+			if feed.Language != "" {
+				fmt.Printf(" language=%s", feed.Language)
+			}
+			for _, hub := range feed.Hubs {
+				fmt.Printf(" hub=%s", hub)
+			}
+			fmt.Println()
 		} else {
 			fmt.Println(feed.URL)
 		}
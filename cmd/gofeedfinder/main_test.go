@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder"
+)
+
+func TestPathList(t *testing.T) {
+	fs := flag.NewFlagSet("gofeedfinder", flag.ContinueOnError)
+	var paths pathList
+	fs.Var(&paths, "scan-paths", "")
+
+	if err := fs.Parse([]string{"--scan-paths", "/custom, /another", "--scan-paths", "/third,", "https://example.com"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	expected := pathList{"/custom", "/another", "/third"}
+	if !cmp.Equal(paths, expected) {
+		t.Errorf("pathList = %v, want %v", paths, expected)
+	}
+	if got := paths.String(); got != "/custom,/another,/third" {
+		t.Errorf("String() = %q, want %q", got, "/custom,/another,/third")
+	}
+	if !cmp.Equal(fs.Args(), []string{"https://example.com"}) {
+		t.Errorf("Args() = %v, want the URL", fs.Args())
+	}
+}
+
+func TestReadURLFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.txt")
+	content := "# blogs to check\nhttps://example.com\n\n  example.org  \n# https://skipped.example\nhttps://example.net/blog\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	urls, err := readURLFile(path)
+	if err != nil {
+		t.Fatalf("readURLFile() unexpected error: %v", err)
+	}
+	expected := []string{"https://example.com", "example.org", "https://example.net/blog"}
+	if !cmp.Equal(urls, expected) {
+		t.Errorf("readURLFile() = %v, want %v", urls, expected)
+	}
+
+	if _, err := readURLFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readURLFile() expected error for a missing file")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "No error", err: nil, expected: 0},
+		{name: "No feeds", err: gofeedfinder.ErrNoFeedsFound, expected: exitNoFeeds},
+		{name: "Invalid URL", err: fmt.Errorf("%w: missing host", gofeedfinder.ErrInvalidURL), expected: exitUsage},
+		{name: "HTTP status", err: &gofeedfinder.HTTPStatusError{StatusCode: 404}, expected: exitFetchError},
+		{name: "Network error", err: &url.Error{Op: "Get", URL: "https://example.com", Err: errors.New("connection refused")}, expected: exitFetchError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.expected {
+				t.Errorf("exitCode(%v) = %d, want %d", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFailuresExitCode(t *testing.T) {
+	fetchErr := &gofeedfinder.HTTPStatusError{StatusCode: 500}
+	invalidErr := fmt.Errorf("%w: missing host", gofeedfinder.ErrInvalidURL)
+	noFeeds := gofeedfinder.ErrNoFeedsFound
+
+	tests := []struct {
+		name     string
+		errs     []error
+		expected int
+	}{
+		{name: "No feeds anywhere", errs: []error{noFeeds, noFeeds}, expected: exitNoFeeds},
+		{name: "Invalid URL after no feeds", errs: []error{noFeeds, invalidErr}, expected: exitUsage},
+		{name: "No feeds after invalid URL", errs: []error{invalidErr, noFeeds}, expected: exitUsage},
+		{name: "Fetch error then invalid URL", errs: []error{fetchErr, invalidErr}, expected: exitFetchError},
+		{name: "Invalid URL then fetch error", errs: []error{invalidErr, fetchErr, noFeeds}, expected: exitFetchError},
+		{name: "Fetch error then no feeds", errs: []error{fetchErr, noFeeds}, expected: exitFetchError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failuresExitCode(tt.errs); got != tt.expected {
+				t.Errorf("failuresExitCode(%v) = %d, want %d", tt.errs, got, tt.expected)
+			}
+		})
+	}
+}
@@ -5,9 +5,8 @@ go 1.24.4
 require (
 	github.com/PuerkitoBio/goquery v1.10.3
 	github.com/google/go-cmp v0.7.0
+	golang.org/x/net v0.39.0
+	golang.org/x/text v0.24.0
 )
 
-require (
-	github.com/andybalholm/cascadia v1.3.3 // indirect
-	golang.org/x/net v0.39.0 // indirect
-)
+require github.com/andybalholm/cascadia v1.3.3 // indirect
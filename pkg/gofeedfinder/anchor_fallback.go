@@ -0,0 +1,73 @@
+package gofeedfinder
+
+import (
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// MaxBodySize limits how much of the HTML document we'll read for the anchor fallback (5MB default)
+const MaxBodySize = 5 * 1024 * 1024
+
+// anchorFeedExtensions are href extensions that suggest an anchor points to a feed
+var anchorFeedExtensions = []string{".rss", ".xml", ".atom"}
+
+// anchorFeedKeywords are words in an anchor's text or rel that suggest it points to a feed
+var anchorFeedKeywords = []string{"rss", "feed", "atom"}
+
+// extractAnchorFeedCandidates returns the absolute URLs of <a> elements in doc that look
+// like feed links, either by href extension or by their text or rel attribute.
+// The candidates are not validated.
+func extractAnchorFeedCandidates(doc *goquery.Document, pageURL string) []string {
+	baseURL := documentBaseURL(doc, pageURL)
+
+	var candidates []string
+	seen := make(map[string]bool)
+	doc.Find("a[href]").Each(func(i int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		href = strings.TrimSpace(href)
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			return
+		}
+
+		rel, _ := s.Attr("rel")
+		if !looksLikeFeedAnchor(href, s.Text(), rel) {
+			return
+		}
+
+		resolved := internal.ResolveFeedURL(href, baseURL)
+		if !seen[resolved] {
+			seen[resolved] = true
+			candidates = append(candidates, resolved)
+		}
+	})
+
+	return candidates
+}
+
+// looksLikeFeedAnchor reports whether an anchor's href, text, or rel suggests a feed.
+func looksLikeFeedAnchor(href, text, rel string) bool {
+	hrefPath := strings.ToLower(href)
+	if i := strings.IndexAny(hrefPath, "?#"); i >= 0 {
+		hrefPath = hrefPath[:i]
+	}
+	ext := path.Ext(hrefPath)
+	for _, feedExt := range anchorFeedExtensions {
+		if ext == feedExt {
+			return true
+		}
+	}
+
+	words := strings.Fields(strings.ToLower(text + " " + rel))
+	for _, word := range words {
+		word = strings.Trim(word, ".,:;!?()[]")
+		for _, keyword := range anchorFeedKeywords {
+			if word == keyword {
+				return true
+			}
+		}
+	}
+	return false
+}
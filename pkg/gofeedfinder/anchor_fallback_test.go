@@ -0,0 +1,107 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractAnchorFeedCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		baseURL  string
+		expected []string
+	}{
+		{
+			name: "Feed extensions",
+			html: `<html><head></head><body>
+				<a href="/feed.xml">Subscribe</a>
+				<a href="/posts.atom">Posts</a>
+				<a href="news.rss?lang=en">News</a>
+				<a href="/about.html">About</a>
+				</body></html>`,
+			baseURL:  "https://example.com/blog/",
+			expected: []string{"https://example.com/feed.xml", "https://example.com/posts.atom", "https://example.com/blog/news.rss?lang=en"},
+		},
+		{
+			name: "Feed keywords in text and rel",
+			html: `<html><body>
+				<a href="/subscribe">RSS</a>
+				<a href="/syndication" rel="feed">Follow</a>
+				<a href="/feedback">Send feedback</a>
+				</body></html>`,
+			baseURL:  "https://example.com",
+			expected: []string{"https://example.com/subscribe", "https://example.com/syndication"},
+		},
+		{
+			name: "Duplicates and non-links are skipped",
+			html: `<html><body>
+				<a href="/feed.xml">RSS</a>
+				<a href="https://example.com/feed.xml">Feed</a>
+				<a href="#rss">RSS</a>
+				<a href="javascript:void(0)">RSS</a>
+				</body></html>`,
+			baseURL:  "https://example.com",
+			expected: []string{"https://example.com/feed.xml"},
+		},
+		{
+			name:     "No anchors",
+			html:     `<html><body><p>Nothing here</p></body></html>`,
+			baseURL:  "https://example.com",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractAnchorFeedCandidates(newHTMLPage(tt.html).document(), tt.baseURL)
+			if !cmp.Equal(result, tt.expected) {
+				t.Errorf("extractAnchorFeedCandidates() = %+v, want %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindFeedsFromReader_AnchorFallback(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.String() == "https://example.com/feed.xml" {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     map[string][]string{"Content-Type": {"application/rss+xml"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	html := `<html><head><title>Old site</title></head><body>
+		<a href="/feed.xml">RSS</a>
+		<a href="/broken.rss">Broken feed</a>
+		</body></html>`
+
+	t.Run("Enabled", func(t *testing.T) {
+		feeds, err := FindFeedsFromReader(strings.NewReader(html), "https://example.com", Options{HTTPClient: client, AnchorFallback: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []Feed{{URL: "https://example.com/feed.xml", Title: "", Type: "rss", Source: SourceLink}}
+		if !cmp.Equal(feeds, expected) {
+			t.Errorf("FindFeedsFromReader() = %+v, want %+v", feeds, expected)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		feeds, err := FindFeedsFromReader(strings.NewReader(html), "https://example.com", Options{HTTPClient: client})
+		if err == nil || feeds != nil {
+			t.Errorf("expected error without anchor fallback, got feeds=%+v, err=%v", feeds, err)
+		}
+	})
+}
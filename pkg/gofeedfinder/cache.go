@@ -0,0 +1,127 @@
+package gofeedfinder
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Cache stores the feeds found by common path scans, keyed by host, so that repeated
+// discoveries on the same site don't repeat the scan. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the cached feeds for host, if present.
+	Get(host string) ([]Feed, bool)
+	// Set stores the feeds found for host.
+	Set(host string, feeds []Feed)
+}
+
+// NoopCache is a Cache that stores nothing. It is used when Options.Cache is nil.
+type NoopCache struct{}
+
+// Get always reports a miss.
+func (NoopCache) Get(host string) ([]Feed, bool) { return nil, false }
+
+// Set discards feeds.
+func (NoopCache) Set(host string, feeds []Feed) {}
+
+// TTLCache is an in-memory Cache whose entries expire after a fixed duration.
+type TTLCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]ttlCacheEntry
+	now     func() time.Time
+}
+
+type ttlCacheEntry struct {
+	feeds   []Feed
+	expires time.Time
+}
+
+// NewTTLCache returns an empty TTLCache whose entries are kept for ttl.
+func NewTTLCache(ttl time.Duration) *TTLCache {
+	return &TTLCache{
+		ttl:     ttl,
+		entries: make(map[string]ttlCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the feeds stored for host unless the entry has expired.
+func (c *TTLCache) Get(host string) ([]Feed, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[host]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, host)
+		return nil, false
+	}
+	return entry.feeds, true
+}
+
+// Set stores feeds for host, replacing any existing entry.
+func (c *TTLCache) Set(host string, feeds []Feed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[host] = ttlCacheEntry{feeds: feeds, expires: c.now().Add(c.ttl)}
+}
+
+// cache returns opts.Cache, or a NoopCache if it's unset.
+func (o Options) cache() Cache {
+	if o.Cache == nil {
+		return NoopCache{}
+	}
+	return o.Cache
+}
+
+// scanCommonFeedPathsCached is scanCommonFeedPathsFunc backed by opts.Cache. On a hit the
+// cached feeds are passed to emit without any requests. A scan that finds feeds and runs to
+// completion is stored; one cut short by emit or by ctx is not, as its results may be partial.
+// Dry runs bypass the cache. Paths checked by a scan are reported to onPath, if set.
+func scanCommonFeedPathsCached(ctx context.Context, baseURL string, opts Options, emit func(Feed) bool, onPath func(path string, err error)) error {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil || opts.DryRun {
+		return scanCommonFeedPathsFunc(ctx, baseURL, opts, emit, onPath)
+	}
+	cache := opts.cache()
+
+	// A scan relative to a subdirectory checks different paths, so it's cached separately
+	key := parsedURL.Host
+	if dir := opts.scanDir(parsedURL); dir != "/" {
+		key += dir
+	}
+
+	if feeds, ok := cache.Get(key); ok {
+		opts.logf("using %d cached common path feeds for %s", len(feeds), key)
+		for _, feed := range feeds {
+			if !emit(feed) {
+				break
+			}
+		}
+		return nil
+	}
+
+	var found []Feed
+	stopped := false
+	err = scanCommonFeedPathsFunc(ctx, baseURL, opts, func(feed Feed) bool {
+		found = append(found, feed)
+		if !emit(feed) {
+			stopped = true
+			return false
+		}
+		return true
+	}, onPath)
+	if err != nil {
+		return err
+	}
+
+	if len(found) > 0 && !stopped && ctx.Err() == nil {
+		cache.Set(key, found)
+	}
+	return nil
+}
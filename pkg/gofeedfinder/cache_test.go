@@ -0,0 +1,95 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := NewTTLCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	feeds := []Feed{{URL: "https://example.com/feed", Type: "rss"}}
+	cache.Set("example.com", feeds)
+
+	if got, ok := cache.Get("example.com"); !ok || !cmp.Equal(got, feeds) {
+		t.Errorf("Get() = %+v, %v, want %+v, true", got, ok, feeds)
+	}
+	if _, ok := cache.Get("other.example.com"); ok {
+		t.Error("Get() hit for a host that was never set")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := cache.Get("example.com"); ok {
+		t.Error("Get() hit for an expired entry")
+	}
+}
+
+func TestFindFeedsWithOptions_Cache(t *testing.T) {
+	var mu sync.Mutex
+	scanRequests := 0
+
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/a", "/b":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<html><head><title>No feeds here</title></head></html>`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+
+		mu.Lock()
+		scanRequests++
+		mu.Unlock()
+		if req.URL.Path == "/feed" {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<?xml version="1.0"?><rss version="2.0"></rss>`)),
+				Header:     map[string][]string{"Content-Type": {"application/rss+xml"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	opts := Options{
+		HTTPClient:      client,
+		ScanCommonPaths: true,
+		Cache:           NewTTLCache(time.Hour),
+	}
+	expected := []Feed{{URL: "https://example.com/feed", Title: "", Type: "rss", Source: SourceScan}}
+
+	feeds, err := FindFeedsWithOptions("https://example.com/a", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("first FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+	if scanRequests == 0 {
+		t.Fatal("expected the first call to scan common paths")
+	}
+
+	firstScan := scanRequests
+	feeds, err = FindFeedsWithOptions("https://example.com/b", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("second FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+	if scanRequests != firstScan {
+		t.Errorf("second call made %d path scan requests, want 0", scanRequests-firstScan)
+	}
+}
@@ -0,0 +1,29 @@
+package gofeedfinder
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// extractCanonicalURL returns the absolute URL of the first <link rel="canonical"> in doc,
+// or "" if there is none. Relative URLs are resolved against pageURL or the document's <base href>.
+func extractCanonicalURL(doc *goquery.Document, pageURL string) string {
+	baseURL := documentBaseURL(doc, pageURL)
+
+	var canonical string
+	doc.Find("link[rel][href]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		rel, _ := s.Attr("rel")
+		if !hasRelToken(rel, "canonical") {
+			return true
+		}
+		href, _ := s.Attr("href")
+		if href = strings.TrimSpace(href); href != "" {
+			canonical = internal.ResolveFeedURL(href, baseURL)
+			return false
+		}
+		return true
+	})
+	return canonical
+}
@@ -0,0 +1,82 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "Absolute canonical",
+			html:     `<html><head><link rel="canonical" href="https://example.com/blog/"></head></html>`,
+			expected: "https://example.com/blog/",
+		},
+		{
+			name:     "Relative canonical",
+			html:     `<html><head><link rel="canonical" href="/feed.xml"></head></html>`,
+			expected: "https://example.com/feed.xml",
+		},
+		{
+			name:     "Canonical among other rel tokens",
+			html:     `<html><head><link rel="Canonical nofollow" href="/feed.xml"></head></html>`,
+			expected: "https://example.com/feed.xml",
+		},
+		{
+			name:     "Similar rel token isn't canonical",
+			html:     `<html><head><link rel="canonical-alt" href="/other.xml"><link rel="canonical" href="/feed.xml"></head></html>`,
+			expected: "https://example.com/feed.xml",
+		},
+		{
+			name:     "No canonical",
+			html:     `<html><head><link rel="stylesheet" href="/style.css"></head></html>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractCanonicalURL(newHTMLPage(tt.html).document(), "https://example.com/page"); got != tt.expected {
+				t.Errorf("extractCanonicalURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindFeedsWithOptions_CanonicalFallback(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/feed.xml" {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<?xml version="1.0"?><rss version="2.0"></rss>`)),
+				Header:     map[string][]string{"Content-Type": {"application/rss+xml"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`<html><head><link rel="canonical" href="/feed.xml"></head><body></body></html>`)),
+			Header:     map[string][]string{"Content-Type": {"text/html"}},
+		}, nil
+	})
+
+	feeds, err := FindFeedsWithOptions("https://example.com/news", Options{HTTPClient: client, CanonicalFallback: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Feed{{URL: "https://example.com/feed.xml", Title: "", Type: "rss", Source: SourceLink}}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+
+	if _, err := FindFeedsWithOptions("https://example.com/news", Options{HTTPClient: client}); err == nil {
+		t.Error("expected ErrNoFeedsFound without CanonicalFallback")
+	}
+}
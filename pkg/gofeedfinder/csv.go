@@ -0,0 +1,25 @@
+package gofeedfinder
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvHeader is the header row written by WriteCSV
+var csvHeader = []string{"url", "title", "type"}
+
+// WriteCSV writes feeds to w as CSV: a "url,title,type" header row followed by one row per
+// feed. Fields containing commas, quotes, or newlines are quoted.
+func WriteCSV(w io.Writer, feeds []Feed) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, feed := range feeds {
+		if err := cw.Write([]string{feed.URL, feed.Title, feed.Type}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
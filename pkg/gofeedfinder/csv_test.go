@@ -0,0 +1,37 @@
+package gofeedfinder
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteCSV(t *testing.T) {
+	feeds := []Feed{
+		{URL: "https://example.com/feed.xml", Title: "News, Updates & More", Type: "rss"},
+		{URL: "https://example.com/atom.xml", Title: "The \"Best\" Blog\nSecond line", Type: "atom"},
+		{URL: "https://example.com/feed.json", Title: "", Type: "json"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, feeds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("produced CSV does not parse: %v", err)
+	}
+
+	expected := [][]string{
+		{"url", "title", "type"},
+		{"https://example.com/feed.xml", "News, Updates & More", "rss"},
+		{"https://example.com/atom.xml", "The \"Best\" Blog\nSecond line", "atom"},
+		{"https://example.com/feed.json", "", "json"},
+	}
+	if !cmp.Equal(records, expected) {
+		t.Errorf("CSV records = %q, want %q", records, expected)
+	}
+}
@@ -0,0 +1,45 @@
+package gofeedfinder
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// DebugResult is what DiscoverWithDebug found, along with details of how discovery went,
+// for diagnosing a site that yields fewer feeds than expected.
+type DebugResult struct {
+	Feeds        []Feed        `json:"feeds"`
+	StatusCode   int           `json:"status_code"`   // Status of the last page fetched, or 0 if none was
+	Header       http.Header   `json:"header"`        // Response headers of the last page fetched
+	PathsScanned int           `json:"paths_scanned"` // Common paths requested by the path scan
+	Duration     time.Duration `json:"duration"`      // How long the whole discovery took
+}
+
+// DiscoverWithDebug discovers feeds on the page at url like FindFeedsWithOptions, and also
+// reports the page's response status and headers, how many common paths were scanned, and
+// how long discovery took. The result is returned even when discovery fails, so a page
+// that yields no feeds can be inspected; it's nil only if the URL is unusable.
+func DiscoverWithDebug(url string, opts Options) (*DebugResult, error) {
+	result := &DebugResult{}
+	start := time.Now()
+	err := findFeedsWithCallback(context.Background(), url, opts, func(feed Feed) {
+		result.Feeds = append(result.Feeds, feed)
+	}, discoveryHooks{
+		onResponse: func(resp *http.Response) {
+			result.StatusCode = resp.StatusCode
+			result.Header = resp.Header.Clone()
+		},
+		onPath: func(path string, err error) {
+			if !errors.Is(err, ErrDisallowedByRobots) {
+				result.PathsScanned++
+			}
+		},
+	})
+	result.Duration = time.Since(start)
+	if errors.Is(err, ErrInvalidURL) {
+		return nil, err
+	}
+	return result, err
+}
@@ -0,0 +1,64 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDiscoverWithDebug(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<html><head><title>No feeds here</title></head></html>`)),
+				Header:     map[string][]string{"Content-Type": {"text/html"}, "Server": {"test"}},
+			}, nil
+		case "/missing":
+			return &http.Response{
+				StatusCode: 404,
+				Body:       io.NopCloser(strings.NewReader("Not Found")),
+				Header:     make(http.Header),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	result, err := DiscoverWithDebug("https://example.com/", Options{HTTPClient: client, ScanCommonPaths: true})
+	if !errors.Is(err, ErrNoFeedsFound) {
+		t.Fatalf("DiscoverWithDebug() error = %v, want ErrNoFeedsFound", err)
+	}
+	if result == nil {
+		t.Fatal("DiscoverWithDebug() returned no result alongside ErrNoFeedsFound")
+	}
+	if result.StatusCode != 200 || result.Header.Get("Server") != "test" {
+		t.Errorf("StatusCode, Server = %d, %q, want 200, %q", result.StatusCode, result.Header.Get("Server"), "test")
+	}
+	if result.PathsScanned != len(commonFeedPaths) {
+		t.Errorf("PathsScanned = %d, want %d", result.PathsScanned, len(commonFeedPaths))
+	}
+	if len(result.Feeds) != 0 || result.Duration <= 0 {
+		t.Errorf("Feeds, Duration = %v, %v, want no feeds and a positive duration", result.Feeds, result.Duration)
+	}
+
+	// A failed page fetch still reports its status, and no paths are scanned without ScanCommonPaths
+	result, err = DiscoverWithDebug("https://example.com/missing", Options{HTTPClient: client})
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("DiscoverWithDebug() error = %v, want *HTTPStatusError", err)
+	}
+	if result.StatusCode != 404 || result.PathsScanned != 0 {
+		t.Errorf("StatusCode, PathsScanned = %d, %d, want 404, 0", result.StatusCode, result.PathsScanned)
+	}
+
+	if result, err := DiscoverWithDebug("://bad", Options{HTTPClient: client}); result != nil || !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("DiscoverWithDebug() with an invalid URL = %v, %v, want nil, ErrInvalidURL", result, err)
+	}
+}
@@ -0,0 +1,199 @@
+package gofeedfinder
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// FeedHeadSize is how much of a fetched document is passed to a Validator (64KB)
+const FeedHeadSize = 64 * 1024
+
+// Validator classifies fetched content as a feed. Validate receives the response's
+// Content-Type header and up to FeedHeadSize bytes from the start of the body, which may
+// be truncated mid-document. Responses with a JSON Content-Type are passed in full, up to
+// Options.MaxResponseSize. It returns the feed type and whether the content is a feed.
+// HTML pages are rejected before a Validator is consulted.
+type Validator interface {
+	Validate(contentType string, head []byte) (feedType string, ok bool)
+}
+
+// DefaultValidator recognizes RSS and RDF ("rss"), Atom ("atom"), and JSON Feed ("json")
+// documents by their content, regardless of the Content-Type.
+type DefaultValidator struct{}
+
+// Validate implements Validator.
+func (DefaultValidator) Validate(contentType string, head []byte) (string, bool) {
+	if bytes.HasPrefix(bytes.TrimSpace(head), []byte("{")) {
+		if _, ok := jsonFeedVersion(bytes.NewReader(head)); ok {
+			return "json", true
+		}
+		return "", false
+	}
+	if feedType, _ := xmlFeedType(bytes.NewReader(head)); feedType != "" {
+		return feedType, true
+	}
+	return "", false
+}
+
+// isHTMLDocument reports whether head is the start of an HTML page: one whose first markup,
+// after any byte order mark, whitespace, comments, and processing instructions, is an
+// HTML doctype or <html> tag. Sites that answer unknown paths with their normal page
+// (a soft 404) are caught this way however the page is labeled or whatever it mentions.
+func isHTMLDocument(head []byte) bool {
+	rest := head
+	for {
+		rest = bytes.TrimLeft(rest, "\ufeff \t\r\n")
+		var end []byte
+		switch {
+		case bytes.HasPrefix(rest, []byte("<!--")):
+			end = []byte("-->")
+		case bytes.HasPrefix(rest, []byte("<?")):
+			end = []byte("?>")
+		default:
+			return hasTagPrefix(rest, "<!doctype html") || hasTagPrefix(rest, "<html")
+		}
+		i := bytes.Index(rest, end)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(end):]
+	}
+}
+
+// hasTagPrefix reports whether b starts with tag, case-insensitively, followed by the end
+// of the tag name.
+func hasTagPrefix(b []byte, tag string) bool {
+	if len(b) < len(tag) || !bytes.EqualFold(b[:len(tag)], []byte(tag)) {
+		return false
+	}
+	return len(b) == len(tag) || strings.IndexByte(" \t\r\n>/", b[len(tag)]) >= 0
+}
+
+// validator returns opts.Validator, or DefaultValidator if it's unset.
+func (o Options) validator() Validator {
+	if o.Validator == nil {
+		return DefaultValidator{}
+	}
+	return o.Validator
+}
+
+// jsonFeedVersionPrefix is the prefix of the version URL every JSON Feed declares
+const jsonFeedVersionPrefix = "https://jsonfeed.org/version/"
+
+// jsonFeedVersion reports whether r holds a JSON Feed document, by streaming through the
+// top-level object until it finds the "version" key and checking its value. It returns
+// the version the feed declares (e.g. "1.1"). Other top-level values are skipped without
+// being decoded.
+func jsonFeedVersion(r io.Reader) (string, bool) {
+	dec := json.NewDecoder(r)
+
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return "", false
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return "", false
+		}
+
+		if key == "version" {
+			var version string
+			if err := dec.Decode(&version); err != nil || !strings.HasPrefix(version, jsonFeedVersionPrefix) {
+				return "", false
+			}
+			return strings.TrimPrefix(version, jsonFeedVersionPrefix), true
+		}
+
+		if err := skipJSONValue(dec); err != nil {
+			return "", false
+		}
+	}
+
+	return "", false
+}
+
+// skipJSONValue consumes the next value from dec, including any nested objects or arrays.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// Namespaces of the root elements xmlFeedType recognizes
+const (
+	atomNamespace   = "http://www.w3.org/2005/Atom"
+	atom03Namespace = "http://purl.org/atom/ns#"
+	rdfNamespace    = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+)
+
+// xmlFeedType returns "atom" or "rss" based on the root element of the XML document in r,
+// or "" if the root is not a feed element. The root is classified by its local name and
+// resolved namespace, so comments, processing instructions, and attribute order before
+// it don't matter: <rss>, <rdf:RDF> in the RDF namespace, and <feed> in the Atom 1.0 or
+// 0.3 namespace are feeds, while a <feed> from any other vocabulary is not. It also
+// returns the format version the root declares: the version attribute of <rss> or Atom
+// 0.3, "1.0" for Atom 1.0 and RSS 1.0 (RDF), or "" if unknown. Only the start of the
+// document is read, so a truncated document is fine as long as it contains the root start tag.
+func xmlFeedType(r io.Reader) (feedType string, version string) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	// Element names are all we need, so pass through any declared encoding untouched
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return "", ""
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var versionAttr string
+		for _, attr := range start.Attr {
+			if attr.Name.Space == "" && attr.Name.Local == "version" {
+				versionAttr = strings.TrimSpace(attr.Value)
+			}
+		}
+
+		switch {
+		case strings.EqualFold(start.Name.Local, "rss"):
+			return "rss", versionAttr
+		case start.Name.Local == "RDF" && (start.Name.Space == rdfNamespace || start.Name.Space == "rdf"):
+			// An undeclared rdf: prefix is left unresolved, which lenient parsing tolerates
+			return "rss", "1.0"
+		case start.Name.Local == "feed" && start.Name.Space == atomNamespace:
+			if versionAttr == "" {
+				versionAttr = "1.0"
+			}
+			return "atom", versionAttr
+		case start.Name.Local == "feed" && start.Name.Space == atom03Namespace:
+			return "atom", versionAttr
+		}
+		return "", ""
+	}
+}
@@ -0,0 +1,233 @@
+package gofeedfinder
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJSONFeedVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected bool
+		version  string
+	}{
+		{
+			name:     "JSON Feed 1.1",
+			content:  `{"version": "https://jsonfeed.org/version/1.1", "title": "My Feed", "items": []}`,
+			expected: true,
+			version:  "1.1",
+		},
+		{
+			name:     "Version after other keys",
+			content:  `{"title": "My Feed", "home_page_url": "https://example.com", "author": {"name": "A"}, "version": "https://jsonfeed.org/version/1"}`,
+			expected: true,
+			version:  "1",
+		},
+		{
+			name:     "Non-feed JSON with version and title",
+			content:  `{"version": "2.3.1", "title": "Release notes", "items": ["fix"]}`,
+			expected: false,
+		},
+		{
+			name:     "Non-string version",
+			content:  `{"version": 1, "title": "API"}`,
+			expected: false,
+		},
+		{
+			name:     "No version key",
+			content:  `{"title": "My Feed", "items": [{"id": "1"}]}`,
+			expected: false,
+		},
+		{
+			name:     "Top-level array",
+			content:  `[{"version": "https://jsonfeed.org/version/1"}]`,
+			expected: false,
+		},
+		{
+			name:     "Truncated after version",
+			content:  `{"version": "https://jsonfeed.org/version/1", "items": [{"id": "1", "content_`,
+			expected: true,
+			version:  "1",
+		},
+		{
+			name:     "Not JSON",
+			content:  `<html><body>Not a feed</body></html>`,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, ok := jsonFeedVersion(strings.NewReader(tt.content))
+			if ok != tt.expected || version != tt.version {
+				t.Errorf("jsonFeedVersion(%q) = %q, %v, want %q, %v", tt.content, version, ok, tt.version, tt.expected)
+			}
+		})
+	}
+}
+
+func TestXMLFeedType(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		version  string
+	}{
+		{
+			name:     "RSS",
+			content:  `<?xml version="1.0"?><rss version="2.0"><channel></channel></rss>`,
+			expected: "rss",
+			version:  "2.0",
+		},
+		{
+			name:     "RDF",
+			content:  `<?xml version="1.0"?><rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"><channel></channel>`,
+			expected: "rss",
+			version:  "1.0",
+		},
+		{
+			name:     "Atom",
+			content:  `<?xml version="1.0" encoding="utf-8"?><feed xmlns="http://www.w3.org/2005/Atom"><title>T</title></feed>`,
+			expected: "atom",
+			version:  "1.0",
+		},
+		{
+			name:     "Prefixed Atom",
+			content:  `<atom:feed xmlns:atom="http://www.w3.org/2005/Atom"><atom:title>T</atom:title></atom:feed>`,
+			expected: "atom",
+			version:  "1.0",
+		},
+		{
+			name: "Atom with xmlns far from the root tag",
+			content: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n<!-- " + strings.Repeat("generated by a static site generator ", 40) + "-->\n<feed\n" +
+				strings.Repeat("  xml:lang=\"en\"\n", 5) + "  xmlns=\"http://www.w3.org/2005/Atom\">\n<title>T</title>",
+			expected: "atom",
+			version:  "1.0",
+		},
+		{
+			name:     "Truncated root",
+			content:  `<?xml version="1.0"?><rss version="2.0"><channel><title>Unfinis`,
+			expected: "rss",
+			version:  "2.0",
+		},
+		{
+			name:     "RSS 0.91",
+			content:  `<?xml version="1.0"?><rss version="0.91"><channel></channel></rss>`,
+			expected: "rss",
+			version:  "0.91",
+		},
+		{
+			name:     "Atom 0.3",
+			content:  `<?xml version="1.0"?><feed version="0.3" xmlns="http://purl.org/atom/ns#"><title>T</title></feed>`,
+			expected: "atom",
+			version:  "0.3",
+		},
+		{
+			name:     "HTML",
+			content:  `<!DOCTYPE html><html><head><title>Feed</title></head><body><feed></feed></body></html>`,
+			expected: "",
+		},
+		{
+			name:     "Sitemap",
+			content:  `<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`,
+			expected: "",
+		},
+		{
+			name:     "Feed element from another vocabulary",
+			content:  `<?xml version="1.0"?><feed xmlns="http://www.example.com/schemas/products"><item>Widget</item></feed>`,
+			expected: "",
+		},
+		{
+			name:     "Feed element without a namespace",
+			content:  `<?xml version="1.0"?><feed><entry>Not Atom</entry></feed>`,
+			expected: "",
+		},
+		{
+			name:     "Atom namespace declared for a prefix only",
+			content:  `<?xml version="1.0"?><feed xmlns="urn:example:events" xmlns:atom="http://www.w3.org/2005/Atom"><atom:link rel="self" href="/events"/></feed>`,
+			expected: "",
+		},
+		{
+			name:     "RDF root from another namespace",
+			content:  `<?xml version="1.0"?><x:RDF xmlns:x="urn:example:not-rdf"></x:RDF>`,
+			expected: "",
+		},
+		{
+			name: "Comments and stylesheet before the root",
+			content: `<?xml version="1.0"?><?xml-stylesheet type="text/xsl" href="/feed.xsl"?>` +
+				`<!-- <feed xmlns="http://www.w3.org/2005/Atom"> --><!DOCTYPE rss><rss
+				  xmlns:atom="http://www.w3.org/2005/Atom"   version = "2.0" ><channel></channel></rss>`,
+			expected: "rss",
+			version:  "2.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feedType, version := xmlFeedType(strings.NewReader(tt.content))
+			if feedType != tt.expected || version != tt.version {
+				t.Errorf("xmlFeedType() = %q, %q, want %q, %q", feedType, version, tt.expected, tt.version)
+			}
+		})
+	}
+}
+
+// newsMLValidator recognizes a made-up <newsstream> format and falls back to the default.
+type newsMLValidator struct{}
+
+func (newsMLValidator) Validate(contentType string, head []byte) (string, bool) {
+	if strings.HasPrefix(contentType, "application/x-newsstream") && bytes.Contains(head, []byte("<newsstream")) {
+		return "newsstream", true
+	}
+	return DefaultValidator{}.Validate(contentType, head)
+}
+
+func TestValidateFeedContent_CustomValidator(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/rss" {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<rss version="2.0"></rss>`)),
+				Header:     make(http.Header),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(`<?xml version="1.0"?><newsstream id="acme"><story/></newsstream>`)),
+			Header:     http.Header{"Content-Type": {"application/x-newsstream+xml"}},
+		}, nil
+	})
+
+	opts := Options{HTTPClient: client, Validator: newsMLValidator{}}
+
+	feed, err := validateFeedContent(context.Background(), "https://example.com/stream", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Feed{URL: "https://example.com/stream", Title: "", Type: "newsstream"}
+	if !cmp.Equal(feed, expected) {
+		t.Errorf("validateFeedContent() = %+v, want %+v", feed, expected)
+	}
+
+	// The built-in formats still work through the custom validator's fallback
+	feed, err = validateFeedContent(context.Background(), "https://example.com/rss", opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = &Feed{URL: "https://example.com/rss", Title: "", Type: "rss", Version: "2.0"}
+	if !cmp.Equal(feed, expected) {
+		t.Errorf("validateFeedContent() = %+v, want %+v", feed, expected)
+	}
+
+	// The default validator doesn't know the custom format
+	if _, err := validateFeedContent(context.Background(), "https://example.com/stream", Options{HTTPClient: client}); err == nil {
+		t.Error("expected the default validator to reject the custom format")
+	}
+}
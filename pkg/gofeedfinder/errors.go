@@ -0,0 +1,48 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNoFeedsFound is returned when discovery completes without finding any feeds.
+var ErrNoFeedsFound = errors.New("no feeds found")
+
+// ErrInvalidURL is returned when the input URL can't be used for discovery.
+var ErrInvalidURL = errors.New("invalid URL")
+
+// ErrNotModified is returned when a conditional page fetch (Options.IfNoneMatch or
+// Options.IfModifiedSince) gets a 304 response, so results from the last discovery still apply.
+var ErrNotModified = errors.New("page not modified")
+
+// ErrDisallowedByRobots is reported for common paths skipped because robots.txt disallows them.
+var ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+// HTTPStatusError is returned when a request completes with a non-2xx status code.
+type HTTPStatusError struct {
+	StatusCode int    // The HTTP status code of the response
+	Method     string // The request method, or empty for the page fetch
+	Location   string // The absolute redirect target of a 3xx response, when redirects aren't followed
+}
+
+// newHTTPStatusError returns an HTTPStatusError for resp, which is the response to a
+// request with the given method ("" for the page fetch).
+func newHTTPStatusError(resp *http.Response, method string) *HTTPStatusError {
+	err := &HTTPStatusError{StatusCode: resp.StatusCode, Method: method}
+	if location, locErr := resp.Location(); locErr == nil {
+		err.Location = location.String()
+	}
+	return err
+}
+
+func (e *HTTPStatusError) Error() string {
+	method := e.Method
+	if method == "" {
+		method = "HTTP"
+	}
+	if e.Location != "" {
+		return fmt.Sprintf("%s request failed with status %d (redirect to %s)", method, e.StatusCode, e.Location)
+	}
+	return fmt.Sprintf("%s request failed with status %d", method, e.StatusCode)
+}
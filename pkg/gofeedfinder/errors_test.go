@@ -0,0 +1,71 @@
+package gofeedfinder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestErrNoFeedsFound(t *testing.T) {
+	_, err := FindFeedsFromReader(strings.NewReader(`<html><head><title>No feeds</title></head></html>`), "https://example.com", Options{})
+	if !errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("expected ErrNoFeedsFound, got %v", err)
+	}
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	_, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client})
+
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("expected *HTTPStatusError, got %T: %v", err, err)
+	}
+	if statusErr.StatusCode != 404 {
+		t.Errorf("StatusCode = %d, want 404", statusErr.StatusCode)
+	}
+	if errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("status error should not match ErrNoFeedsFound")
+	}
+
+	_, err = checkFeedURL(context.Background(), "https://example.com/feed", Options{HTTPClient: client})
+	if !errors.As(err, &statusErr) || statusErr.Method != http.MethodHead {
+		t.Errorf("expected HEAD *HTTPStatusError from checkFeedURL, got %v", err)
+	}
+}
+
+func TestMalformedBaseURL(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, errors.New("no requests expected")
+	})
+	opts := Options{HTTPClient: client, ScanCommonPaths: true}
+
+	_, err := FindFeedsFromReader(strings.NewReader(`<html><head><title>No feeds</title></head></html>`), "https://[::1", opts)
+	if !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("expected ErrInvalidURL, got %v", err)
+	}
+	var parseErr *url.Error
+	if !errors.As(err, &parseErr) || parseErr.Op != "parse" {
+		t.Errorf("expected the url.Parse error to surface, got %v", err)
+	}
+	if errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("malformed base URL should not match ErrNoFeedsFound")
+	}
+
+	// A base URL without a host can't be scanned either
+	if _, err := ScanCommonFeedPathsWithOptions("example.com/blog", opts); !errors.Is(err, ErrInvalidURL) {
+		t.Errorf("expected ErrInvalidURL for a base URL without a host, got %v", err)
+	}
+}
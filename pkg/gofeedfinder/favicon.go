@@ -0,0 +1,54 @@
+package gofeedfinder
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// extractIconURL returns the absolute URL of the first <link rel="icon"> (or "shortcut icon")
+// in doc, or "" if there is none. Relative URLs are resolved against pageURL or the
+// document's <base href>.
+func extractIconURL(doc *goquery.Document, pageURL string) string {
+	baseURL := documentBaseURL(doc, pageURL)
+
+	var icon string
+	doc.Find("link[rel][href]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		rel, _ := s.Attr("rel")
+		if !hasRelToken(rel, "icon") {
+			return true
+		}
+		href, _ := s.Attr("href")
+		if href = strings.TrimSpace(href); href != "" {
+			icon = internal.ResolveFeedURL(href, baseURL)
+			return false
+		}
+		return true
+	})
+	return icon
+}
+
+// findSiteIcon returns the icon linked in page, falling back to the site's /favicon.ico if
+// a HEAD request shows it exists. It returns "" if the site has no icon.
+func findSiteIcon(ctx context.Context, page *htmlPage, baseURL string, opts Options) string {
+	if icon := extractIconURL(page.document(), baseURL); icon != "" {
+		return icon
+	}
+
+	fallback := internal.ResolveFeedURL("/favicon.ico", baseURL)
+	resp, err := opts.doRequest(ctx, http.MethodHead, fallback)
+	if err != nil {
+		opts.logf("no icon for %s: %v", baseURL, err)
+		return ""
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		opts.logf("no icon for %s: %s returned %d", baseURL, fallback, resp.StatusCode)
+		return ""
+	}
+	return fallback
+}
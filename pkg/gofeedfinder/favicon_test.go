@@ -0,0 +1,105 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractIconURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "Icon link",
+			html:     `<html><head><link rel="icon" href="/icon.png"></head></html>`,
+			expected: "https://example.com/icon.png",
+		},
+		{
+			name:     "Shortcut icon link",
+			html:     `<html><head><link rel="Shortcut Icon" href="https://cdn.example.com/favicon.ico"></head></html>`,
+			expected: "https://cdn.example.com/favicon.ico",
+		},
+		{
+			name:     "Apple touch icon only",
+			html:     `<html><head><link rel="apple-touch-icon" href="/touch.png"></head></html>`,
+			expected: "",
+		},
+		{
+			name:     "No icon",
+			html:     `<html><head><link rel="stylesheet" href="/style.css"></head></html>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractIconURL(newHTMLPage(tt.html).document(), "https://example.com/page"); got != tt.expected {
+				t.Errorf("extractIconURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindFeedsWithOptions_DiscoverFavicon(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		favicon  int
+		expected string
+	}{
+		{
+			name:     "Explicit icon link",
+			head:     `<link rel="shortcut icon" href="/static/icon.png">`,
+			favicon:  http.StatusNotFound,
+			expected: "https://example.com/static/icon.png",
+		},
+		{
+			name:     "Falls back to /favicon.ico",
+			favicon:  http.StatusOK,
+			expected: "https://example.com/favicon.ico",
+		},
+		{
+			name:     "No icon",
+			favicon:  http.StatusNotFound,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := testClient(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/favicon.ico" {
+					if req.Method != http.MethodHead {
+						t.Errorf("favicon requested with %s, want HEAD", req.Method)
+					}
+					return &http.Response{
+						StatusCode: tt.favicon,
+						Body:       io.NopCloser(strings.NewReader("")),
+						Header:     make(http.Header),
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body: io.NopCloser(strings.NewReader(`<html><head>` + tt.head +
+						`<link rel="alternate" type="application/rss+xml" href="/feed.xml"></head></html>`)),
+					Header: map[string][]string{"Content-Type": {"text/html"}},
+				}, nil
+			})
+
+			feeds, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client, DiscoverFavicon: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := []Feed{{URL: "https://example.com/feed.xml", Type: "rss", Source: SourceLink, SiteIcon: tt.expected}}
+			if !cmp.Equal(feeds, expected) {
+				t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+			}
+		})
+	}
+}
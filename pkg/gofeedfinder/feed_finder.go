@@ -3,16 +3,31 @@ package gofeedfinder
 import (
 	"bufio"
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"mime"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/encoding/unicode/utf32"
 )
 
 // MIME type constants for feed detection
@@ -21,185 +36,1366 @@ const (
 	MimeTypeAtom     = "application/atom+xml"
 	MimeTypeJSON     = "application/json"
 	MimeTypeFeedJSON = "application/feed+json"
+	MimeTypeXML      = "application/xml"
+	MimeTypeTextXML  = "text/xml"
 )
 
 // MaxHeadSize limits how much of the HTML head section we'll read (1MB default)
 const MaxHeadSize = 1024 * 1024
 
 // MaxLineSize limits the maximum size of a single line when scanning HTML (1MB default)
+//
+// Deprecated: the head is tokenized rather than scanned line by line, so no line limit applies.
 const MaxLineSize = 1024 * 1024
 
+// Path scan methods for Options.PathScanMethod
+const (
+	// PathScanHeadGet makes a HEAD request first and only GETs the body when the
+	// Content-Type is ambiguous or the server doesn't support HEAD. This is the default.
+	PathScanHeadGet = "head-get"
+	// PathScanGetOnly skips the HEAD request and validates the body of a GET request.
+	PathScanGetOnly = "get-only"
+)
+
+// DefaultUserAgent is the User-Agent sent when Options.UserAgent is empty
+const DefaultUserAgent = "gofeedfinder/1.0"
+
+// DefaultFeedAccept is the Accept header sent when checking a candidate feed URL and
+// Options.FeedAccept is empty. It prefers the feed media types, then generic XML, then anything.
+const DefaultFeedAccept = "application/rss+xml, application/atom+xml, application/feed+json, application/xml;q=0.9, text/xml;q=0.9, */*;q=0.8"
+
+// DefaultMaxRedirects is the number of redirects followed when Options.MaxRedirects is zero
+const DefaultMaxRedirects = 10
+
+// DefaultMaxConcurrency is the number of concurrent path checks when Options.MaxConcurrency is zero
+const DefaultMaxConcurrency = 3
+
+// DefaultTimeout is the per-request timeout used when Options.Timeout is zero
+const DefaultTimeout = 15 * time.Second
+
+// DefaultMaxResponseSize is the most of any response body read when Options.MaxResponseSize is zero (5MB)
+const DefaultMaxResponseSize = 5 * 1024 * 1024
+
 // Feed represents a discovered feed with its URL, title, and type.
 type Feed struct {
-	URL   string // The absolute URL of the feed
-	Title string // Optional title of the feed
-	Type  string // Feed type: "rss", "atom", or "json"
+	URL   string `json:"url"`   // The absolute URL of the feed
+	Title string `json:"title"` // Optional title of the feed
+	Type  string `json:"type"`  // Feed type: "rss", "atom", "json", or "microformats"
+
+	// Version is the format version the feed declares, such as "2.0" for RSS 2.0 or "1.1"
+	// for JSON Feed 1.1. It's only set when the feed's content was fetched.
+	Version string `json:"version,omitempty"`
+
+	// Hubs lists WebSub (PubSubHubbub) hub URLs advertised for the feed, if any.
+	Hubs []string `json:"hubs,omitempty"`
+
+	// ETag and LastModified hold the feed response's validators for later conditional requests.
+	// They are only set when the feed itself was fetched and the server sent them.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+
+	// Source records how the feed was discovered: SourceLink, SourceScan, SourceSite, or
+	// SourceSitemap.
+	// It's empty for feeds checked directly with CheckFeedURL.
+	Source string `json:"source,omitempty"`
+
+	// SiteIcon is the absolute URL of the icon of the site the feed was found on. It's
+	// only set with Options.DiscoverFavicon, and only when the site has an icon.
+	SiteIcon string `json:"site_icon,omitempty"`
+
+	// Kind tells a site's main feed (KindMain) from its comments feed (KindComments). It's
+	// only set when a page links both, judging by whether the link's title or URL
+	// mentions comments.
+	Kind string `json:"kind,omitempty"`
+
+	// Language is the hreflang of the <link> the feed was found in, such as "en" or
+	// "pt-BR", for sites that link a feed per language. It's empty if the link has none.
+	Language string `json:"language,omitempty"`
 }
 
-// Options configures feed discovery behavior
+// CandidateFeedType is the Feed.Type of the unchecked URLs reported by a DryRun scan.
+const CandidateFeedType = "candidate"
+
+// Feed kinds, recorded in Feed.Kind.
+const (
+	KindMain     = "main"
+	KindComments = "comments"
+)
+
+// Feed sources, recorded in Feed.Source.
+const (
+	// SourceLink marks feeds found in the page's HTML: a <link> element, or with the
+	// fallbacks enabled a feed-like <a>, the canonical link, or h-feed markup.
+	SourceLink = "link"
+	// SourceScan marks feeds found by probing common paths.
+	SourceScan = "scan"
+	// SourceSite marks feeds from a known site's predictable feed URL.
+	SourceSite = "site"
+	// SourceSitemap marks feeds found among the URLs listed in the site's sitemaps.
+	SourceSitemap = "sitemap"
+)
+
+// Options configures feed discovery behavior.
+// The zero value is usable: unset fields fall back to the defaults returned by DefaultOptions.
 type Options struct {
 	ScanCommonPaths bool // Whether to scan common feed paths when no feeds found in HTML
-	MaxConcurrency  int  // Maximum concurrent requests for path scanning (default: 3)
+	MaxConcurrency  int  // Maximum concurrent requests for path scanning (default: DefaultMaxConcurrency)
+
+	// SortBy orders the returned feeds: SortNone (the default) keeps discovery order,
+	// while SortURL, SortType, and SortTitle sort by that field, keeping feeds with equal
+	// keys in discovery order. Feeds passed to a callback are never sorted.
+	SortBy string
+
+	// IncludeTypes restricts results to the given feed types ("rss", "atom", "json").
+	// An empty slice returns all types.
+	IncludeTypes []string
+
+	// MaxRedirects limits how many redirects are followed per request (default: DefaultMaxRedirects).
+	// A negative value disables following: a 3xx response fails with an HTTPStatusError
+	// whose Location holds the redirect target, for callers auditing redirects.
+	MaxRedirects int
+
+	// UserAgent is sent as the User-Agent header on every request (default: DefaultUserAgent).
+	UserAgent string
+
+	// FeedAccept is sent as the Accept header when checking whether a URL is a feed, as
+	// path scans and VerifyFeeds do, so servers that negotiate content serve the feed
+	// rather than an HTML page (default: DefaultFeedAccept). An Accept in Headers wins.
+	FeedAccept string
+
+	// Timeout bounds each HTTP request, including reading its body (default: DefaultTimeout).
+	Timeout time.Duration
+
+	// MaxTotalDuration bounds a whole discovery, from the page fetch through any path scan.
+	// When it runs out, the feeds found so far are returned, or context.DeadlineExceeded
+	// if there are none. Zero means no overall limit.
+	MaxTotalDuration time.Duration
+
+	// MaxResponseSize caps how many bytes of any response body are read, after
+	// decompression, so a hostile server can't exhaust memory by streaming an endless
+	// body (default: DefaultMaxResponseSize). Content past the cap is ignored.
+	MaxResponseSize int
+
+	// EnableSiteSpecific checks known sites (such as Reddit) that expose feeds at
+	// predictable URLs before fetching the page.
+	EnableSiteSpecific bool
+
+	// HTTPClient, if set, sends every request in place of a client built from the options.
+	// Its Transport, CheckRedirect, and Timeout are kept when set; MaxRedirects and Timeout
+	// fill in those left unset. ProxyURL and InsecureSkipVerify are ignored if the client
+	// has its own Transport.
+	HTTPClient *http.Client
+
+	// Cookies are sent with requests, for feeds behind a logged-in session. They're added
+	// once to the client's cookie jar (HTTPClient.Jar if set, otherwise a jar of their own)
+	// for the input page URL, so a cookie with a Domain is sent to that domain, while one
+	// without is scoped to the input page's host and never sent to other hosts, such as
+	// those of linked feeds.
+	Cookies []*http.Cookie
+
+	// Headers are added to every outgoing request, overriding the defaults.
+	Headers http.Header
+
+	// IfNoneMatch and IfModifiedSince, if set, make the page fetch conditional, using the
+	// ETag and Last-Modified from an earlier fetch. If the server answers 304 Not Modified,
+	// discovery stops with ErrNotModified. Path scans and feed checks are unaffected.
+	IfNoneMatch     string
+	IfModifiedSince time.Time
+
+	// ProxyURL, if set, routes every request through the given proxy. Both HTTP proxies
+	// ("http://proxy:3128") and SOCKS5 proxies ("socks5://proxy:1080") are supported,
+	// with credentials in the URL's userinfo if needed.
+	ProxyURL string
+
+	// InsecureSkipVerify disables TLS certificate verification, for intranet hosts that
+	// serve feeds with self-signed certificates. Connections are then open to
+	// interception by anyone on the network path, so only enable it for hosts you
+	// trust and never for arbitrary user-supplied URLs.
+	InsecureSkipVerify bool
+
+	// BasicAuth, if set, sends HTTP basic auth credentials on every request.
+	BasicAuth *BasicAuth
+
+	// AnchorFallback scans <a> elements in the page body for feed-like links when no
+	// <link> feeds are found. Each candidate is validated before it's returned.
+	AnchorFallback bool
+
+	// VerifyFeeds checks each feed advertised in the HTML and drops those that are
+	// unreachable or don't look like a feed.
+	VerifyFeeds bool
+
+	// Strict only accepts a feed if its response declares a feed Content-Type (an RSS, Atom,
+	// JSON, or generic XML type), even if the body looks like a feed. This drops <link>
+	// feeds that point at mislabeled HTML pages. Strict implies VerifyFeeds, and applies to
+	// every feed check, including the path scan.
+	Strict bool
+
+	// EnrichTitles fetches each feed linked without a title attribute and takes its title
+	// from the feed document's channel or feed title.
+	EnrichTitles bool
+
+	// MaxFeeds caps the number of feeds returned; path scanning stops once it's reached.
+	// Zero means unlimited.
+	MaxFeeds int
+
+	// RespectRobots fetches the site's robots.txt before path scanning and skips
+	// any common path it disallows for our User-Agent.
+	RespectRobots bool
+
+	// PathScanMethod controls how candidate feed URLs are checked: PathScanHeadGet
+	// (default) or PathScanGetOnly for servers that don't support HEAD.
+	PathScanMethod string
+
+	// Logger, if set, receives a log of each request, its response status, and the
+	// discovery decisions made. Logging is silent when nil.
+	Logger *log.Logger
+
+	// Metrics, if set, is notified of each request and how long its response took.
+	Metrics *Metrics
+
+	// AllowHTTPFallback retries over http:// when a URL given without a scheme
+	// can't be fetched over https://.
+	AllowHTTPFallback bool
+
+	// AdaptiveConcurrency starts path scans with one request at a time, doubling the
+	// concurrency after fast responses and halving it after 429s, 503s, or timeouts.
+	// MaxConcurrency is the ceiling.
+	AdaptiveConcurrency bool
+
+	// MaxRetries is how many times a request answered with 429 Too Many Requests is retried,
+	// after waiting for the response's Retry-After (default: no retries). Waits longer than
+	// the request's context allows fail with the context's error.
+	MaxRetries int
+
+	// Validator decides whether fetched content is a feed, and of which type
+	// (default: DefaultValidator). Set it to recognize additional feed formats.
+	Validator Validator
+
+	// RequestDelay enforces a minimum gap between path scan requests to the same host.
+	// Combined with MaxConcurrency it keeps scans under a site's rate limits.
+	RequestDelay time.Duration
+
+	// SiteConcurrency limits how many pages FindFeedsForURLs discovers at once
+	// (default: DefaultSiteConcurrency). Each page's path scan is separately bounded
+	// by MaxConcurrency.
+	SiteConcurrency int
+
+	// RelFeed also treats the non-standard <link rel="feed"> as a feed link. The type comes
+	// from the link's type attribute or href extension; links whose type can't be inferred
+	// are checked with a request and kept only if they're feeds. It's off by default
+	// because rel="feed" is sometimes used for things that aren't feeds.
+	RelFeed bool
+
+	// LooseMIME also treats <link rel="alternate"> elements typed as generic XML
+	// (application/xml or text/xml) as feed candidates. Some CMSs advertise their feeds
+	// that way. Each candidate is checked with a request and kept only if its content is
+	// a feed, which also decides its type.
+	LooseMIME bool
+
+	// CustomFeedPaths are extra paths (such as "/blog/rss") for the common path scan to
+	// check, ahead of the built-in ones.
+	CustomFeedPaths []string
+
+	// ScanRelativeToPath has the common path scan also check each path under the
+	// directory of the URL being scanned, ahead of the host root, for sites hosted below
+	// the root: scanning https://example.com/blog/ checks /blog/feed as well as /feed.
+	// The directory ends at the last slash, so /blog/ and /blog/index.html give /blog/
+	// but /blog gives the root.
+	ScanRelativeToPath bool
+
+	// DryRun makes common path scans report each URL they would check, in priority order,
+	// as a Feed of type CandidateFeedType instead of requesting it. No requests are made
+	// for the scan, so robots.txt isn't consulted. The page itself is still fetched by
+	// FindFeedsWithOptions.
+	DryRun bool
+
+	// FollowMetaRefresh follows a <meta http-equiv="refresh"> in a page without <link>
+	// feeds and runs discovery on its target instead, for landing pages that redirect
+	// that way. At most MaxMetaRefreshHops refreshes are followed, and never back to a
+	// page already seen. If the target can't be fetched, the original page is used.
+	FollowMetaRefresh bool
+
+	// ScanOnly skips fetching the page and goes straight to the common path scan (with
+	// any CustomFeedPaths), saving a request on hosts known not to link their feeds, as
+	// when bulk scanning a platform with predictable feed URLs. Fallbacks that need the
+	// page, such as AnchorFallback and WordPress detection, don't run.
+	ScanOnly bool
+
+	// MergeHTMLAndScan runs the common path scan (when ScanCommonPaths is set) even if
+	// the page links feeds, and returns both sets, deduplicated. Some sites only link a
+	// comments feed and keep the main feed at a path like /feed.
+	MergeHTMLAndScan bool
+
+	// DiscoverFavicon sets Feed.SiteIcon on feeds found from a page, using the page's
+	// <link rel="icon"> or, failing that, the site's /favicon.ico if a HEAD request
+	// shows it exists.
+	DiscoverFavicon bool
+
+	// CanonicalFallback checks the page's <link rel="canonical"> target when no <link>
+	// feeds are found, and returns it if it's a feed. This helps with misconfigured
+	// sites whose feed is only reachable through the canonical link.
+	CanonicalFallback bool
+
+	// SameHostOnly drops feeds whose host differs from the input URL's host, such as
+	// feeds hosted on a third-party service.
+	SameHostOnly bool
+
+	// AllowedHosts restricts feeds to the listed hosts. Combined with SameHostOnly, a
+	// feed is kept if it's on the input URL's host or on one of these hosts.
+	AllowedHosts []string
+
+	// WordPress adds the WordPress feed paths (/feed/, /?feed=rss2, ...) to the front of a
+	// common path scan. It's turned on automatically for pages IsWordPress recognizes;
+	// set it to force the WordPress paths for any site.
+	WordPress bool
+
+	// UseSitemap is a last resort for sparse sites: when nothing else finds a feed, the
+	// URLs listed in the site's sitemaps (those declared in robots.txt, or /sitemap.xml)
+	// that look like feeds are checked, up to MaxSitemapURLs of them.
+	UseSitemap bool
+
+	// Microformats treats the page itself as a feed, with Type "microformats", when no
+	// <link> feeds are found but the body contains an h-feed with h-entry items.
+	Microformats bool
+
+	// Cache, if set, stores the feeds found by common path scans per host (and per
+	// directory with ScanRelativeToPath), so later discoveries on the same host skip the
+	// scan. See NewTTLCache.
+	Cache Cache
+}
+
+// DefaultOptions returns the Options used by FindFeeds, with every defaulted field
+// filled in. It's a convenient starting point for overriding individual fields.
+func DefaultOptions() Options {
+	return Options{
+		MaxConcurrency:  DefaultMaxConcurrency,
+		MaxRedirects:    DefaultMaxRedirects,
+		UserAgent:       DefaultUserAgent,
+		FeedAccept:      DefaultFeedAccept,
+		Timeout:         DefaultTimeout,
+		PathScanMethod:  PathScanHeadGet,
+		SiteConcurrency: DefaultSiteConcurrency,
+		MaxResponseSize: DefaultMaxResponseSize,
+		SortBy:          SortNone,
+	}
+}
+
+// BasicAuth holds HTTP basic auth credentials.
+type BasicAuth struct {
+	User string
+	Pass string
+}
+
+// withCookies returns o with Cookies added to its client's cookie jar for pageURL, the
+// input URL of a discovery or check. The jar is HTTPClient.Jar if set, otherwise a new
+// one shared by all of the returned Options' requests.
+func (o Options) withCookies(pageURL string) Options {
+	if len(o.Cookies) == 0 {
+		return o
+	}
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return o
+	}
+
+	client := &http.Client{}
+	if o.HTTPClient != nil {
+		c := *o.HTTPClient
+		client = &c
+	}
+	if client.Jar == nil {
+		// cookiejar.New never returns an error
+		client.Jar, _ = cookiejar.New(nil)
+	}
+	client.Jar.SetCookies(u, o.Cookies)
+	o.HTTPClient = client
+	return o
+}
+
+// httpClient returns an HTTP client configured from the options, starting from a copy
+// of HTTPClient if it's set. It fails only if ProxyURL is set but unusable.
+func (o Options) httpClient() (*http.Client, error) {
+	maxRedirects := o.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = DefaultMaxRedirects
+	}
+
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	client := &http.Client{}
+	if o.HTTPClient != nil {
+		c := *o.HTTPClient
+		client = &c
+	}
+
+	if client.Transport == nil {
+		transport, err := o.transport()
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = transport
+	}
+	if client.Timeout == 0 {
+		client.Timeout = timeout
+	}
+	if client.CheckRedirect == nil {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if maxRedirects < 0 {
+				return http.ErrUseLastResponse
+			}
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			return nil
+		}
+	}
+	return client, nil
+}
+
+// transportKey identifies a transport built for ProxyURL and InsecureSkipVerify.
+type transportKey struct {
+	proxyURL string
+	insecure bool
+}
+
+// transports caches the transports built by Options.transport, so requests with the same
+// settings share one pool of keep-alive connections instead of each dialing their own.
+var transports sync.Map // transportKey -> *http.Transport
+
+// transport returns the RoundTripper requests are sent with: http.DefaultTransport, or
+// a copy of it adjusted for ProxyURL and InsecureSkipVerify when those are set. Either
+// way, connections are kept alive and reused, and HTTP/2 is used where servers support it.
+func (o Options) transport() (http.RoundTripper, error) {
+	if o.ProxyURL == "" && !o.InsecureSkipVerify {
+		return http.DefaultTransport, nil
+	}
+
+	key := transportKey{proxyURL: o.ProxyURL, insecure: o.InsecureSkipVerify}
+	if transport, ok := transports.Load(key); ok {
+		return transport.(*http.Transport), nil
+	}
+
+	var transport *http.Transport
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = t.Clone()
+	} else {
+		transport = &http.Transport{ForceAttemptHTTP2: true}
+	}
+
+	if o.ProxyURL != "" {
+		proxyURL, err := url.Parse(o.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("invalid proxy URL: unsupported scheme %q", proxyURL.Scheme)
+		}
+		if proxyURL.Host == "" {
+			return nil, errors.New("invalid proxy URL: missing host")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if o.InsecureSkipVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	actual, _ := transports.LoadOrStore(key, transport)
+	return actual.(*http.Transport), nil
+}
+
+// conditional returns a copy of o whose Headers include the If-None-Match and
+// If-Modified-Since headers for IfNoneMatch and IfModifiedSince, for the page fetch.
+func (o Options) conditional() Options {
+	if o.IfNoneMatch == "" && o.IfModifiedSince.IsZero() {
+		return o
+	}
+
+	headers := o.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+	if o.IfNoneMatch != "" {
+		headers.Set("If-None-Match", o.IfNoneMatch)
+	}
+	if !o.IfModifiedSince.IsZero() {
+		headers.Set("If-Modified-Since", o.IfModifiedSince.UTC().Format(http.TimeFormat))
+	}
+	o.Headers = headers
+	return o
+}
+
+// userAgent returns the configured User-Agent, or DefaultUserAgent if none is set.
+func (o Options) userAgent() string {
+	if o.UserAgent == "" {
+		return DefaultUserAgent
+	}
+	return o.UserAgent
+}
+
+// feedAccept returns the configured FeedAccept, or DefaultFeedAccept if none is set.
+func (o Options) feedAccept() string {
+	if o.FeedAccept == "" {
+		return DefaultFeedAccept
+	}
+	return o.FeedAccept
+}
+
+// maxResponseSize returns the configured MaxResponseSize, or DefaultMaxResponseSize if none is set.
+func (o Options) maxResponseSize() int64 {
+	if o.MaxResponseSize <= 0 {
+		return DefaultMaxResponseSize
+	}
+	return int64(o.MaxResponseSize)
+}
+
+// withBudget returns ctx bounded by MaxTotalDuration, if set.
+func (o Options) withBudget(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.MaxTotalDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, o.MaxTotalDuration)
+}
+
+// logf writes to the configured Logger, if any. log.Logger is safe for concurrent use.
+func (o Options) logf(format string, args ...any) {
+	if o.Logger != nil {
+		o.Logger.Printf(format, args...)
+	}
+}
+
+// doRequest issues a request with the given method using the configured client and headers.
+// A 429 response is retried up to MaxRetries times, after waiting as long as its Retry-After asks.
+func (o Options) doRequest(ctx context.Context, method, url string) (*http.Response, error) {
+	return o.sendRequest(ctx, method, url, "")
+}
+
+// doFeedRequest is doRequest for a URL that's expected to be a feed, sending FeedAccept
+// as the Accept header.
+func (o Options) doFeedRequest(ctx context.Context, method, url string) (*http.Response, error) {
+	return o.sendRequest(ctx, method, url, o.feedAccept())
+}
+
+// sendRequest implements doRequest and doFeedRequest, sending accept as the Accept
+// header unless it's empty or Headers sets one.
+func (o Options) sendRequest(ctx context.Context, method, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", o.userAgent())
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	// Setting Accept-Encoding ourselves disables the transport's transparent
+	// gzip handling, so responses are decompressed by decodeResponseBody.
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	for name, values := range o.Headers {
+		req.Header[http.CanonicalHeaderKey(name)] = slices.Clone(values)
+	}
+	if o.BasicAuth != nil {
+		req.SetBasicAuth(o.BasicAuth.User, o.BasicAuth.Pass)
+	}
+
+	client, err := o.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		o.logf("%s %s", method, url)
+		o.Metrics.onRequest(method, url)
+		start := time.Now()
+		resp, err = client.Do(req)
+		if err != nil {
+			o.Metrics.onResponse(url, 0, time.Since(start))
+			o.logf("%s %s failed: %v", method, url, err)
+			return nil, err
+		}
+		o.Metrics.onResponse(url, resp.StatusCode, time.Since(start))
+		o.logf("%s %s -> %d", method, url, resp.StatusCode)
+
+		if resp.StatusCode != http.StatusTooManyRequests || attempt >= o.MaxRetries {
+			break
+		}
+		resp.Body.Close()
+		if err := waitRetryAfter(ctx, resp.Header.Get("Retry-After"), o); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := decodeResponseBody(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if resp.Body != nil {
+		resp.Body = limitedBody{Reader: io.LimitReader(resp.Body, o.maxResponseSize()), Closer: resp.Body}
+	}
+	return resp, nil
+}
+
+// decodeResponseBody replaces resp.Body with a decompressing reader when the
+// response has a gzip or deflate Content-Encoding, or when the body starts with the
+// gzip magic bytes whatever the headers say.
+func decodeResponseBody(resp *http.Response) error {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+
+	var decoded io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		zr, err := gzip.NewReader(resp.Body)
+		if err == io.EOF {
+			// Empty bodies (e.g. HEAD responses) have nothing to decompress
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decompress gzip response: %w", err)
+		}
+		decoded = zr
+	case "deflate":
+		decoded = newDeflateReader(resp.Body)
+	default:
+		zr, ok := sniffGzip(resp)
+		if !ok {
+			return nil
+		}
+		decoded = zr
+	}
+
+	resp.Body = &decodedBody{ReadCloser: decoded, raw: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return nil
+}
+
+// sniffGzip checks whether resp.Body is gzip data that the server didn't label as
+// such, as some misconfigured servers send, and returns a decompressing reader if so.
+// Otherwise resp.Body is left readable from the start.
+func sniffGzip(resp *http.Response) (io.ReadCloser, bool) {
+	// A small buffer keeps the peek from reading far past MaxResponseSize.
+	body := resp.Body
+	br := bufio.NewReaderSize(body, 16)
+	resp.Body = limitedBody{Reader: br, Closer: body}
+	magic, err := br.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return nil, false
+	}
+
+	// The gzip header is longer than the peek, so keep what parsing it reads in case
+	// it turns out not to be gzip after all
+	header := &headerRecorder{r: br}
+	zr, err := gzip.NewReader(header)
+	if err != nil {
+		resp.Body = limitedBody{Reader: io.MultiReader(&header.buf, br), Closer: body}
+		return nil, false
+	}
+	header.stop()
+	return zr, true
+}
+
+// headerRecorder keeps a copy of the bytes read through it until stopped. It's an
+// io.ByteReader, so a gzip.Reader reads through it without buffering ahead.
+type headerRecorder struct {
+	r       *bufio.Reader
+	buf     bytes.Buffer
+	stopped bool
+}
+
+func (h *headerRecorder) Read(p []byte) (int, error) {
+	n, err := h.r.Read(p)
+	if !h.stopped {
+		h.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+func (h *headerRecorder) ReadByte() (byte, error) {
+	b, err := h.r.ReadByte()
+	if err == nil && !h.stopped {
+		h.buf.WriteByte(b)
+	}
+	return b, err
+}
+
+// stop ends recording and drops the copy.
+func (h *headerRecorder) stop() {
+	h.stopped = true
+	h.buf = bytes.Buffer{}
+}
+
+// newDeflateReader decompresses an HTTP "deflate" body. The spec calls for a
+// zlib stream, but some servers send raw DEFLATE data, so both are accepted.
+func newDeflateReader(r io.Reader) io.ReadCloser {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(2)
+	if err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		if zr, err := zlib.NewReader(br); err == nil {
+			return zr
+		}
+	}
+	return flate.NewReader(br)
+}
+
+// decodedBody closes both the decompressing reader and the underlying response body.
+type decodedBody struct {
+	io.ReadCloser
+	raw io.ReadCloser
+}
+
+func (b *decodedBody) Close() error {
+	b.ReadCloser.Close()
+	return b.raw.Close()
+}
+
+// limitedBody reads at most MaxResponseSize bytes of a response body and closes the whole body.
+type limitedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// finalURL returns the URL of the request that produced resp, which differs from
+// the requested URL when redirects were followed. It falls back to requestURL.
+func finalURL(resp *http.Response, requestURL string) string {
+	if resp.Request != nil && resp.Request.URL != nil {
+		return resp.Request.URL.String()
+	}
+	return requestURL
 }
 
 // FindFeeds discovers feed links on the provided web page URL.
 // It returns a slice of discovered Feed objects or an error if the page
 // cannot be accessed or no feeds are found.
+// A non-2xx page response yields an *HTTPStatusError and finding no feeds yields ErrNoFeedsFound.
 func FindFeeds(url string) ([]Feed, error) {
-	return FindFeedsWithOptions(url, Options{})
+	return FindFeedsWithOptions(url, DefaultOptions())
 }
 
 // FindFeedsWithOptions discovers feed links on the provided web page URL with configurable options.
+// A URL without a scheme, such as "example.com", is fetched over https://; URLs that
+// can't be used at all yield an error wrapping ErrInvalidURL. A file:// URL or a local
+// path (absolute, or starting with "./" or "../") is read from disk instead, without
+// the common path scan.
 // It returns a slice of discovered Feed objects or an error if the page
-// cannot be accessed or no feeds are found.
+// cannot be accessed or no feeds are found. Results that aren't Valid, such as a link
+// whose href couldn't be resolved, are dropped.
 func FindFeedsWithOptions(url string, opts Options) ([]Feed, error) {
-	resp, err := http.Get(url)
+	return FindFeedsWithContext(context.Background(), url, opts)
+}
+
+// FindFeedsWithContext discovers feed links like FindFeedsWithOptions, stopping when ctx
+// is done. If ctx (or opts.MaxTotalDuration) expires partway through, the feeds found
+// so far are returned; if none were found, the context's error is returned.
+func FindFeedsWithContext(ctx context.Context, url string, opts Options) ([]Feed, error) {
+	var feeds []Feed
+	err := findFeedsWithCallback(ctx, url, opts, func(feed Feed) {
+		feeds = append(feeds, feed)
+	}, discoveryHooks{})
 	if err != nil {
 		return nil, err
 	}
+
+	// Drop malformed results, such as a relative href that failed to resolve
+	feeds = slices.DeleteFunc(feeds, func(feed Feed) bool {
+		if opts.validFeed(feed) {
+			return false
+		}
+		opts.logf("dropping invalid feed %q (type %q)", feed.URL, feed.Type)
+		return true
+	})
+	if len(feeds) == 0 {
+		return nil, ErrNoFeedsFound
+	}
+	sortFeeds(feeds, opts.SortBy)
+	return feeds, nil
+}
+
+// FindFeedsWithCallback discovers feed links on the provided web page URL like FindFeedsWithOptions,
+// but passes each feed to cb as soon as it's discovered instead of collecting them.
+// Calls to cb are serialized, even when feeds are found by concurrent path scans.
+// It returns ErrNoFeedsFound if cb was never called.
+func FindFeedsWithCallback(url string, opts Options, cb func(Feed)) error {
+	return findFeedsWithCallback(context.Background(), url, opts, cb, discoveryHooks{})
+}
+
+// findFeedsWithCallback implements FindFeedsWithCallback and FindFeedsWithContext,
+// bounding the whole discovery by opts.MaxTotalDuration. Details of the discovery
+// are reported to hooks as it runs.
+func findFeedsWithCallback(ctx context.Context, url string, opts Options, cb func(Feed), hooks discoveryHooks) error {
+	ctx, cancel := opts.withBudget(ctx)
+	defer cancel()
+
+	if path, ok := internal.LocalFilePath(url); ok {
+		return findLocalFileFeeds(ctx, path, opts, cb, hooks)
+	}
+
+	rawURL := url
+	url, addedScheme, err := internal.NormalizeURL(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w %q: %v", ErrInvalidURL, rawURL, err)
+	}
+
+	opts = opts.withCookies(url)
+	c := newFeedCollector(opts, url, cb)
+	c.hooks = hooks
+
+	if opts.EnableSiteSpecific {
+		for _, feed := range findSiteSpecificFeeds(ctx, url, opts) {
+			c.add(feed)
+		}
+		if c.count() > 0 {
+			opts.logf("found %d site-specific feeds for %s", c.count(), url)
+			return nil
+		}
+	}
+
+	if opts.ScanOnly {
+		opts.logf("skipping the page fetch, scanning common paths of %s", url)
+		if err := scanCommonFeedPathsCached(ctx, url, opts, c.add, hooks.onPath); err != nil {
+			return err
+		}
+		if c.count() > 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return ErrNoFeedsFound
+	}
+
+	resp, err := opts.conditional().doRequest(ctx, http.MethodGet, url)
+	if err != nil && addedScheme && opts.AllowHTTPFallback && ctx.Err() == nil {
+		url = "http://" + strings.TrimPrefix(url, "https://")
+		opts.logf("https failed, falling back to %s", url)
+		resp, err = opts.conditional().doRequest(ctx, http.MethodGet, url)
+	}
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
+	if hooks.onResponse != nil {
+		hooks.onResponse(resp)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		return ErrNotModified
+	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP request failed with status %d", resp.StatusCode)
+		return newHTTPStatusError(resp, "")
 	}
 
-	feeds, err := ExtractFeedLinksFromStream(resp.Body, url)
+	body, err := newCharsetReader(resp.Body, resp.Header.Get("Content-Type"))
 	if err != nil {
+		return err
+	}
+
+	return findFeedsFromReader(ctx, body, finalURL(resp, url), opts, c)
+}
+
+// newCharsetReader converts an HTML stream to UTF-8 based on a leading byte order mark,
+// the charset in the Content-Type header or, failing that, a <meta charset> near the top
+// of the document. Documents that declare no charset are assumed to already be UTF-8.
+// The byte order mark is dropped, so the result starts with the document's markup.
+func newCharsetReader(r io.Reader, contentType string) (io.Reader, error) {
+	br := bufio.NewReaderSize(r, 1024)
+	peek, err := br.Peek(1024)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	// A byte order mark overrides any declared charset
+	if e, n := bomEncoding(peek); n > 0 {
+		br.Discard(n)
+		if e == nil {
+			return br, nil
+		}
+		return e.NewDecoder().Reader(br), nil
+	}
+
+	e, name, certain := charset.DetermineEncoding(peek, contentType)
+	if name == "utf-8" {
+		return br, nil
+	}
+	// DetermineEncoding falls back to windows-1252 when nothing is declared
+	if !certain && name == "windows-1252" && !bytes.Contains(bytes.ToLower(peek), []byte("charset")) {
+		return br, nil
+	}
+
+	return e.NewDecoder().Reader(br), nil
+}
+
+// bomEncoding returns the encoding named by the byte order mark at the start of b and
+// the mark's length, or a zero length if b has none. The encoding is nil for UTF-8,
+// which needs no decoding. The UTF-32 marks are checked first, as the little-endian
+// one begins with the UTF-16 one.
+func bomEncoding(b []byte) (encoding.Encoding, int) {
+	switch {
+	case bytes.HasPrefix(b, []byte{0xef, 0xbb, 0xbf}):
+		return nil, 3
+	case bytes.HasPrefix(b, []byte{0x00, 0x00, 0xfe, 0xff}):
+		return utf32.UTF32(utf32.BigEndian, utf32.IgnoreBOM), 4
+	case bytes.HasPrefix(b, []byte{0xff, 0xfe, 0x00, 0x00}):
+		return utf32.UTF32(utf32.LittleEndian, utf32.IgnoreBOM), 4
+	case bytes.HasPrefix(b, []byte{0xfe, 0xff}):
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), 2
+	case bytes.HasPrefix(b, []byte{0xff, 0xfe}):
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), 2
+	}
+	return nil, 0
+}
+
+// FindFeedsFromReader discovers feed links in HTML read from r, without fetching the page itself.
+// The baseURL is used to resolve relative URLs and, if opts.ScanCommonPaths is set,
+// as the site to scan for common feed paths when the HTML contains no feeds.
+func FindFeedsFromReader(r io.Reader, baseURL string, opts Options) ([]Feed, error) {
+	opts = opts.withCookies(baseURL)
+	ctx, cancel := opts.withBudget(context.Background())
+	defer cancel()
+
+	var feeds []Feed
+	c := newFeedCollector(opts, baseURL, func(feed Feed) {
+		feeds = append(feeds, feed)
+	})
+	if err := findFeedsFromReader(ctx, r, baseURL, opts, c); err != nil {
 		return nil, err
 	}
-	
-	// If we found feeds via HTML parsing, return them
-	if len(feeds) > 0 {
-		return feeds, nil
+	sortFeeds(feeds, opts.SortBy)
+	return feeds, nil
+}
+
+// findFeedsFromReader implements FindFeedsFromReader, passing discovered feeds to c.
+func findFeedsFromReader(ctx context.Context, r io.Reader, baseURL string, opts Options, c *feedCollector) error {
+	// The body fallbacks need the whole document, so keep what the head extraction reads.
+	// WordPress detection for the path scan, rel="feed" and generic XML links, the site
+	// icon, and meta refreshes only need the head.
+	var page bytes.Buffer
+	needsPage := opts.AnchorFallback || opts.Microformats || opts.CanonicalFallback
+	if needsPage || opts.ScanCommonPaths || opts.DiscoverFavicon || opts.RelFeed || opts.LooseMIME || opts.FollowMetaRefresh || c.hooks.onPage != nil {
+		r = io.TeeReader(io.LimitReader(r, MaxBodySize), &page)
+	}
+
+	feeds, err := ExtractFeedLinksFromStream(r, baseURL)
+	if err != nil {
+		return err
+	}
+
+	if needsPage && len(feeds) == 0 {
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			return err
+		}
+	}
+	html := newHTMLPage(page.String())
+
+	if opts.FollowMetaRefresh && len(feeds) == 0 {
+		if followed, err := followMetaRefresh(ctx, html, baseURL, opts, c); followed {
+			return err
+		}
+	}
+
+	if opts.DiscoverFavicon {
+		c.icon = findSiteIcon(ctx, html, baseURL, opts)
+	}
+	if c.hooks.onPage != nil {
+		c.hooks.onPage(html)
+	}
+
+	return discoverFeeds(ctx, feeds, html, baseURL, opts, c)
+}
+
+// discoverFeeds passes the feeds found in a page's <link> tags to c, verifying them if
+// configured. If there are none, it falls back to the anchors in page (when AnchorFallback
+// is set), the canonical link (when CanonicalFallback is set), h-feed markup (when
+// Microformats is set), to scanning common paths (when ScanCommonPaths is set), and then
+// to the site's sitemaps (when UseSitemap is set).
+// With RelFeed and LooseMIME, rel="feed" and generic XML links count as <link> feeds. With MergeHTMLAndScan, the path
+// scan also runs when <link> feeds were found.
+func discoverFeeds(ctx context.Context, linkFeeds []Feed, page *htmlPage, baseURL string, opts Options, c *feedCollector) error {
+	if opts.RelFeed {
+		linkFeeds = append(linkFeeds, findRelFeedLinks(ctx, page.document(), baseURL, opts)...)
+	}
+	if opts.LooseMIME {
+		linkFeeds = append(linkFeeds, findGenericXMLLinks(ctx, page.document(), baseURL, opts)...)
+	}
+	if opts.VerifyFeeds || opts.Strict {
+		linkFeeds = verifyFeeds(ctx, linkFeeds, opts)
+	}
+	if opts.EnrichTitles {
+		enrichTitles(ctx, linkFeeds, opts)
+	}
+	for _, feed := range linkFeeds {
+		c.add(feed)
+	}
+
+	// If we found feeds via HTML parsing, stop here unless they're merged with a path scan
+	if c.count() > 0 {
+		opts.logf("found %d feeds in <link> tags", c.count())
+		if opts.MergeHTMLAndScan && opts.ScanCommonPaths {
+			opts.logf("merging with common path scan of %s", baseURL)
+			if err := scanPageFeedPaths(ctx, page, baseURL, opts, c); err != nil {
+				opts.logf("common path scan of %s failed: %v", baseURL, err)
+			}
+		}
+		return nil
+	}
+
+	// If no <link> feeds found and the anchor fallback is enabled, check feed-like anchors
+	if opts.AnchorFallback {
+		candidates := extractAnchorFeedCandidates(page.document(), baseURL)
+		opts.logf("no feed <link> tags, checking %d feed-like anchors", len(candidates))
+		for _, feed := range checkFeedURLs(ctx, candidates, opts) {
+			if feed != nil {
+				feed.Source = SourceLink
+				c.add(*feed)
+			}
+		}
+		if c.count() > 0 {
+			return nil
+		}
 	}
-	
+
+	// A misconfigured site may point its canonical link at the feed
+	if opts.CanonicalFallback {
+		if canonical := extractCanonicalURL(page.document(), baseURL); canonical != "" && canonical != baseURL {
+			opts.logf("no feed <link> tags, checking canonical link %s", canonical)
+			if feed, err := checkFeedURL(ctx, canonical, opts); err == nil {
+				feed.Source = SourceLink
+				c.add(*feed)
+				return nil
+			}
+		}
+	}
+
+	// If the page marks up its posts as an h-feed, the page itself is the feed
+	if opts.Microformats {
+		if feed, ok := findMicroformatsFeed(page.document(), baseURL); ok {
+			opts.logf("no feed <link> tags, using h-feed markup on %s", baseURL)
+			c.add(feed)
+			return nil
+		}
+	}
+
 	// If no feeds found and scanning is enabled, try common paths
 	if opts.ScanCommonPaths {
-		commonFeeds, err := ScanCommonFeedPaths(url, opts.MaxConcurrency)
-		if err != nil {
-			return nil, err
+		opts.logf("no feeds found in HTML, falling back to common path scan of %s", baseURL)
+		if err := scanPageFeedPaths(ctx, page, baseURL, opts, c); err != nil {
+			return err
+		}
+		if c.count() > 0 {
+			return nil
+		}
+	}
+
+	// As a last resort, check the feed-like URLs the site's sitemaps list
+	if opts.UseSitemap && ctx.Err() == nil {
+		opts.logf("no feeds found, checking the sitemaps of %s", baseURL)
+		for _, feed := range findSitemapFeeds(ctx, baseURL, opts) {
+			c.add(feed)
+		}
+		if c.count() > 0 {
+			return nil
+		}
+	}
+
+	// Running out of time isn't the same as there being no feeds
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ErrNoFeedsFound
+}
+
+// scanPageFeedPaths scans common feed paths on baseURL's host, passing feeds to c. The
+// WordPress feed paths are included when page looks like a WordPress site.
+func scanPageFeedPaths(ctx context.Context, page *htmlPage, baseURL string, opts Options, c *feedCollector) error {
+	if !opts.WordPress && IsWordPress(page.html) {
+		opts.logf("%s looks like WordPress, adding WordPress feed paths", baseURL)
+		opts.WordPress = true
+	}
+	return scanCommonFeedPathsCached(ctx, baseURL, opts, c.add, c.hooks.onPath)
+}
+
+// DiscoverFromHTMLString discovers feeds in an HTML string with the full discovery behavior
+// of FindFeedsWithOptions, minus the page fetch. Unlike FindFeedsFromReader, the whole
+// document is parsed, not just the head. If no feeds are linked and opts.ScanCommonPaths
+// is set, common feed paths are scanned on baseURL's host.
+func DiscoverFromHTMLString(html, baseURL string, opts Options) ([]Feed, error) {
+	opts = opts.withCookies(baseURL)
+	ctx, cancel := opts.withBudget(context.Background())
+	defer cancel()
+
+	var feeds []Feed
+	c := newFeedCollector(opts, baseURL, func(feed Feed) {
+		feeds = append(feeds, feed)
+	})
+	page := newHTMLPage(html)
+	if err := discoverFeeds(ctx, extractDocumentFeedLinks(page.document(), baseURL), page, baseURL, opts, c); err != nil {
+		return nil, err
+	}
+	sortFeeds(feeds, opts.SortBy)
+	return feeds, nil
+}
+
+// feedCollector dedupes and filters discovered feeds before passing them to a callback.
+// It is safe for concurrent use; calls to the callback are serialized.
+type feedCollector struct {
+	mu       sync.Mutex
+	types    []string
+	hosts    []string // Allowed feed hosts; empty allows any host
+	maxFeeds int
+	seen     map[string]bool // Canonical URLs (see CanonicalFeedURL) of the feeds emitted
+	icon     string          // Set as SiteIcon on feeds that don't have one
+	visited  map[string]bool // Pages seen while following meta refreshes
+	hooks    discoveryHooks
+	emit     func(Feed)
+}
+
+// discoveryHooks receive details of a discovery as it runs, for the APIs that report more
+// than the feeds. Any of them may be nil.
+type discoveryHooks struct {
+	onPage     func(page *htmlPage)         // The page HTML read by findFeedsFromReader
+	onResponse func(resp *http.Response)    // Each page response, including meta refresh targets
+	onPath     func(path string, err error) // Each path checked by a common path scan
+}
+
+// newFeedCollector returns a feedCollector that applies the filters in opts and passes feeds to emit.
+// The pageURL is the input URL that SameHostOnly compares feed hosts against.
+func newFeedCollector(opts Options, pageURL string, emit func(Feed)) *feedCollector {
+	var hosts []string
+	if opts.SameHostOnly {
+		if u, err := url.Parse(pageURL); err == nil {
+			hosts = append(hosts, strings.ToLower(u.Hostname()))
 		}
-		if len(commonFeeds) > 0 {
-			return commonFeeds, nil
+	}
+	for _, host := range opts.AllowedHosts {
+		hosts = append(hosts, strings.ToLower(host))
+	}
+
+	return &feedCollector{
+		types:    opts.IncludeTypes,
+		hosts:    hosts,
+		maxFeeds: opts.MaxFeeds,
+		seen:     make(map[string]bool),
+		emit:     emit,
+	}
+}
+
+// add passes feed to the callback unless an equivalent URL was already seen, its type or host is filtered
+// out, or the MaxFeeds cap has been reached. It reports whether more feeds can be accepted.
+func (c *feedCollector) add(feed Feed) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.full() {
+		return false
+	}
+	key := CanonicalFeedURL(feed.URL)
+	if !c.seen[key] && (len(c.types) == 0 || slices.Contains(c.types, feed.Type)) && c.hostAllowed(feed.URL) {
+		c.seen[key] = true
+		if feed.SiteIcon == "" {
+			feed.SiteIcon = c.icon
 		}
+		c.emit(feed)
+	}
+	return !c.full()
+}
+
+// hostAllowed reports whether feedURL's host passes the SameHostOnly and AllowedHosts filters.
+func (c *feedCollector) hostAllowed(feedURL string) bool {
+	if len(c.hosts) == 0 {
+		return true
 	}
-	
-	return nil, errors.New("no feeds found")
+	u, err := url.Parse(feedURL)
+	if err != nil {
+		return false
+	}
+	return slices.Contains(c.hosts, strings.ToLower(u.Hostname()))
+}
+
+// full reports whether the MaxFeeds cap has been reached. The caller must hold c.mu.
+func (c *feedCollector) full() bool {
+	return c.maxFeeds > 0 && len(c.seen) >= c.maxFeeds
+}
+
+// count returns the number of feeds passed to the callback so far.
+func (c *feedCollector) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.seen)
+}
+
+// ResolveURL resolves a possibly relative href against base the same way discovered feed
+// links are resolved, for callers doing their own extraction. If either URL can't be
+// parsed, href is returned unchanged.
+func ResolveURL(href, base string) string {
+	return internal.ResolveFeedURL(href, base)
 }
 
 // ExtractFeedLinks extracts feed links from an HTML string.
 // It searches for <link> elements with appropriate rel and type attributes
 // that indicate RSS, Atom, or JSON feeds.
-// The url is used to resolve relative URLs to absolute ones.
+// The url is used to resolve relative URLs to absolute ones, unless the document
+// declares a <base href>, in which case that base is used instead.
+// Any WebSub hubs advertised with <link rel="hub"> are attached to every feed found.
 func ExtractFeedLinks(html string, url string) []Feed {
+	return extractDocumentFeedLinks(newHTMLPage(html).document(), url)
+}
+
+// extractDocumentFeedLinks implements ExtractFeedLinks on an already parsed document.
+func extractDocumentFeedLinks(doc *goquery.Document, url string) []Feed {
 	feeds := []Feed{}
+	baseURL := documentBaseURL(doc, url)
 
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
-	if err != nil {
-		return []Feed{}
-	}
+	var hubs []string
+	doc.Find("link").Each(func(i int, s *goquery.Selection) {
+		rel, _ := s.Attr("rel")
+		href, _ := s.Attr("href")
+		if hasRelToken(rel, "hub") && href != "" {
+			hubs = append(hubs, internal.ResolveFeedURL(href, baseURL))
+		}
+	})
 
 	doc.Find("link").Each(func(i int, s *goquery.Selection) {
 		href, _ := s.Attr("href")
 		title, _ := s.Attr("title")
 		rel, _ := s.Attr("rel")
-		rel = strings.ToLower(rel)
 		linkType, _ := s.Attr("type")
 		linkType = strings.ToLower(linkType)
+		hreflang, _ := s.Attr("hreflang")
 
-		if rel == "alternate" && href != "" {
-			var feedType string
-			switch linkType {
-			case MimeTypeRSS:
-				feedType = "rss"
-			case MimeTypeAtom:
-				feedType = "atom"
-			case MimeTypeJSON, MimeTypeFeedJSON:
-				feedType = "json"
-			}
-
-			if feedType != "" {
-				resolvedURL := internal.ResolveFeedURL(href, url)
+		if hasRelToken(rel, "alternate") && href != "" {
+			if feedType := linkFeedType(linkType); feedType != "" {
+				resolvedURL := internal.ResolveFeedURL(href, baseURL)
 				feeds = append(feeds, Feed{
-					URL:   resolvedURL,
-					Title: title,
-					Type:  feedType,
+					URL:      resolvedURL,
+					Title:    title,
+					Type:     feedType,
+					Hubs:     hubs,
+					Source:   SourceLink,
+					Language: strings.TrimSpace(hreflang),
 				})
 			}
 		}
 	})
 
+	tagFeedKinds(feeds)
 	return feeds
 }
 
+// linkFeedType returns the feed type for a lower-cased <link> type attribute, or "" if
+// it isn't a feed MIME type.
+func linkFeedType(linkType string) string {
+	switch linkType {
+	case MimeTypeRSS:
+		return "rss"
+	case MimeTypeAtom:
+		return "atom"
+	case MimeTypeJSON, MimeTypeFeedJSON:
+		return "json"
+	}
+	return ""
+}
+
+// tagFeedKinds sets Kind on feeds linked from one page if they include both comments
+// feeds, whose title or URL mentions "comment", and main feeds. Otherwise it leaves
+// them untagged.
+func tagFeedKinds(feeds []Feed) {
+	comments := 0
+	for _, feed := range feeds {
+		if isCommentsFeed(feed) {
+			comments++
+		}
+	}
+	if comments == 0 || comments == len(feeds) {
+		return
+	}
+	for i := range feeds {
+		feeds[i].Kind = KindMain
+		if isCommentsFeed(feeds[i]) {
+			feeds[i].Kind = KindComments
+		}
+	}
+}
+
+// isCommentsFeed reports whether feed's title or URL mentions comments.
+func isCommentsFeed(feed Feed) bool {
+	return strings.Contains(strings.ToLower(feed.Title), "comment") || strings.Contains(strings.ToLower(feed.URL), "comment")
+}
+
+// hasRelToken reports whether the space-separated rel attribute value contains token,
+// ignoring case, so rel="alternate home" has the "alternate" token.
+func hasRelToken(rel, token string) bool {
+	for _, t := range strings.Fields(rel) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
+
 // ExtractFeedLinksFromStream extracts feed links from an HTML stream.
 // It only reads the HTML head section to optimize memory usage and performance.
-// The stream reading stops when </head> is encountered or MaxHeadSize is reached.
+// The stream reading stops when </head> or <body> is encountered or MaxHeadSize is reached.
+// The links found are the same as ExtractFeedLinks finds in the head, but the stream is
+// tokenized rather than parsed into a document.
 func ExtractFeedLinksFromStream(reader io.Reader, baseURL string) ([]Feed, error) {
-	limitedReader := io.LimitReader(reader, MaxHeadSize)
-	
-	headHTML, err := extractHeadSection(limitedReader)
+	feeds, err := extractHeadFeedLinks(io.LimitReader(reader, MaxHeadSize), baseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract head section: %w", err)
 	}
-	
-	if len(headHTML) == 0 {
-		return []Feed{}, nil
-	}
-	
-	return ExtractFeedLinks(headHTML, baseURL), nil
-}
-
-// extractHeadSection reads from the input stream and extracts only the HTML head section.
-// It stops reading when it encounters </head> or reaches the size limit.
-func extractHeadSection(reader io.Reader) (string, error) {
-	var headBuffer bytes.Buffer
-	scanner := bufio.NewScanner(reader)
-	scanner.Buffer(make([]byte, 0, 64*1024), MaxLineSize) // Allow up to MaxLineSize per line
-	
-	inHead := false
-	headStartFound := false
-	
-	for scanner.Scan() {
-		line := scanner.Text()
-		lineLower := strings.ToLower(line)
-		
-		// Look for opening <head> tag
-		if !headStartFound && strings.Contains(lineLower, "<head") {
-			inHead = true
-			headStartFound = true
-		}
-		
-		// If we haven't found head yet but found body, give up
-		if !headStartFound && strings.Contains(lineLower, "<body") {
-			break
-		}
-		
-		// If we're in the head section, write the line to buffer
-		if inHead {
-			headBuffer.WriteString(line)
-			headBuffer.WriteString("\n")
+	return feeds, nil
+}
+
+// extractHeadFeedLinks collects the feed <link> elements in the head of the HTML in
+// reader, as read by readHeadLinks, without building a document.
+func extractHeadFeedLinks(reader io.Reader, baseURL string) ([]Feed, error) {
+	links, err := readHeadLinks(reader, baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var hubs []string
+	for _, l := range links {
+		if hasRelToken(l.Rel, "hub") && l.Href != "" {
+			hubs = append(hubs, l.URL)
 		}
-		
-		// Look for closing </head> tag
-		if inHead && strings.Contains(lineLower, "</head>") {
-			break
+	}
+
+	feeds := []Feed{}
+	for _, l := range links {
+		if !hasRelToken(l.Rel, "alternate") || l.Href == "" {
+			continue
 		}
-		
-		// If we're in the head section but encounter body without proper </head>, abort
-		if inHead && strings.Contains(lineLower, "<body") && !strings.Contains(lineLower, "</head>") {
-			return "", nil
+		if feedType := linkFeedType(l.Type); feedType != "" {
+			feeds = append(feeds, Feed{
+				URL:      l.URL,
+				Title:    l.Title,
+				Type:     feedType,
+				Hubs:     hubs,
+				Source:   SourceLink,
+				Language: l.Hreflang,
+			})
 		}
 	}
-	
-	if err := scanner.Err(); err != nil {
-		return "", err
-	}
-	
-	return headBuffer.String(), nil
+
+	tagFeedKinds(feeds)
+	return feeds, nil
 }
 
-// Common feed paths to check, ordered by likelihood
+// Common feed paths to check, ordered by likelihood. A path may carry a query string for
+// platforms that serve several formats at one path, like Blogger's ?alt=rss.
 var commonFeedPaths = []string{
 	"/feed",
 	"/rss",
@@ -209,124 +1405,433 @@ var commonFeedPaths = []string{
 	"/feed.xml",
 	"/feeds/all.atom.xml",
 	"/feeds/posts/default",
+	"/feeds/posts/default?alt=rss",
 	"/api/rss",
 	"/feed.rss",
 }
 
+// CommonFeedPaths returns the built-in paths a common path scan checks, in priority order.
+// The result is a copy, so changing it doesn't affect scanning; use Options.CustomFeedPaths
+// to scan more paths.
+func CommonFeedPaths() []string {
+	return slices.Clone(commonFeedPaths)
+}
+
 // ScanCommonFeedPaths scans common feed paths on a domain when no feeds are found via HTML parsing.
-// It uses controlled concurrency to check multiple paths simultaneously.
+// It uses controlled concurrency to check multiple paths simultaneously. The feeds are
+// returned in path priority order (e.g. /feed before /rss), regardless of which responds first.
+// A baseURL that can't be parsed or has no host fails with an error wrapping ErrInvalidURL.
 func ScanCommonFeedPaths(baseURL string, maxConcurrency int) ([]Feed, error) {
+	return scanCommonFeedPaths(baseURL, Options{MaxConcurrency: maxConcurrency})
+}
+
+// ScanCommonFeedPathsWithOptions scans common feed paths like ScanCommonFeedPaths, using the
+// HTTP, path, and concurrency settings from opts. With opts.DryRun, it returns the URLs
+// it would check without making any requests.
+func ScanCommonFeedPathsWithOptions(baseURL string, opts Options) ([]Feed, error) {
+	return ScanCommonFeedPathsWithContext(context.Background(), baseURL, opts)
+}
+
+// ScanCommonFeedPathsWithContext scans common feed paths like ScanCommonFeedPathsWithOptions,
+// stopping when ctx is done. Outstanding requests are aborted, and the feeds confirmed
+// before then are returned along with ctx.Err(), so callers with a deadline can use
+// partial results.
+func ScanCommonFeedPathsWithContext(ctx context.Context, baseURL string, opts Options) ([]Feed, error) {
+	opts = opts.withCookies(baseURL)
+	var feeds []Feed
+	err := scanCommonFeedPathsFunc(ctx, baseURL, opts, func(feed Feed) bool {
+		feeds = append(feeds, feed)
+		return true
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return feeds, ctx.Err()
+}
+
+// ScanCommonFeedPathsDetailed scans common feed paths like ScanCommonFeedPaths, and also
+// returns the error for each path that didn't yield a feed, keyed by path (e.g. "/rss").
+// This is useful for telling apart paths that are absent, unauthorized, or timed out.
+func ScanCommonFeedPathsDetailed(baseURL string, maxConcurrency int) ([]Feed, map[string]error, error) {
+	return scanCommonFeedPathsDetailed(baseURL, Options{MaxConcurrency: maxConcurrency})
+}
+
+// scanCommonFeedPaths implements ScanCommonFeedPaths using the HTTP settings from opts.
+func scanCommonFeedPaths(baseURL string, opts Options) ([]Feed, error) {
+	feeds, _, err := scanCommonFeedPathsDetailed(baseURL, opts)
+	return feeds, err
+}
+
+// scanCommonFeedPathsDetailed implements ScanCommonFeedPathsDetailed using the HTTP settings from opts.
+func scanCommonFeedPathsDetailed(baseURL string, opts Options) ([]Feed, map[string]error, error) {
+	var feeds []Feed
+	pathErrors := make(map[string]error)
+	err := scanCommonFeedPathsFunc(context.Background(), baseURL, opts, func(feed Feed) bool {
+		feeds = append(feeds, feed)
+		return true
+	}, func(path string, err error) {
+		if err != nil {
+			pathErrors[path] = err
+		}
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return feeds, pathErrors, nil
+}
+
+// scanCommonFeedPathsFunc scans common feed paths like scanCommonFeedPaths, passing each
+// feed to emit and, if onPath is non-nil, each path's result to onPath: a nil error for a
+// feed, otherwise the reason it failed or was skipped. Feeds are emitted in the priority
+// order of opts.scanPaths(), as soon as every higher-priority path has been checked.
+// Calls to emit and onPath are serialized.
+// If emit returns false, outstanding path checks are cancelled.
+func scanCommonFeedPathsFunc(ctx context.Context, baseURL string, opts Options, emit func(Feed) bool, onPath func(path string, err error)) error {
+	maxConcurrency := opts.MaxConcurrency
 	if maxConcurrency <= 0 {
-		maxConcurrency = 3
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
+	parsedURL, err := parseBaseURL(baseURL)
+	if err != nil {
+		return err
+	}
+
+	// A dry run reports the URLs without requesting anything, robots.txt included
+	if opts.DryRun {
+		for _, feedPath := range opts.scanPathsFor(parsedURL) {
+			fullURL := parsedURL.Scheme + "://" + parsedURL.Host + feedPath
+			if !emit(Feed{URL: fullURL, Type: CandidateFeedType, Source: SourceScan}) {
+				break
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Fetched once per scan; nil rules allow every path
+	var robots *robotsRules
+	if opts.RespectRobots {
+		robots = fetchRobotsRules(ctx, parsedURL.Scheme, parsedURL.Host, opts)
+	}
+
+	// Limit concurrency, and use a throttle to space out requests
+	limiter := newConcurrencyLimiter(maxConcurrency, opts.AdaptiveConcurrency)
+	throttle := newHostThrottle(opts.RequestDelay)
+	var emitMu sync.Mutex
+	var wg sync.WaitGroup
+
+	// Checks finish in any order, but feeds are emitted in path priority order: a feed
+	// is held until every path before it has been checked
+	paths := opts.scanPathsFor(parsedURL)
+	results := make([]*Feed, len(paths))
+	done := make([]bool, len(paths))
+	next := 0
+	stopped := false
+	finish := func(i int, feed *Feed) {
+		results[i] = feed
+		done[i] = true
+		for next < len(done) && done[next] {
+			// Stop the scan once emit has had enough
+			if results[next] != nil && !stopped && !emit(*results[next]) {
+				stopped = true
+				cancel()
+			}
+			next++
+		}
+	}
+
+	// Launch goroutines for each path
+	for i, path := range paths {
+		if !robots.allowed(path) {
+			opts.logf("skipping %s: %v", path, ErrDisallowedByRobots)
+			emitMu.Lock()
+			if onPath != nil {
+				onPath(path, ErrDisallowedByRobots)
+			}
+			finish(i, nil)
+			emitMu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(i int, feedPath string) {
+			defer wg.Done()
+			limiter.acquire()
+
+			if err := throttle.wait(ctx, parsedURL.Host); err != nil {
+				limiter.release(err, 0)
+				emitMu.Lock()
+				finish(i, nil)
+				emitMu.Unlock()
+				return
+			}
+
+			fullURL := parsedURL.Scheme + "://" + parsedURL.Host + feedPath
+			start := time.Now()
+			feed, err := checkFeedURL(ctx, fullURL, opts)
+			limiter.release(err, time.Since(start))
+
+			emitMu.Lock()
+			defer emitMu.Unlock()
+			if err != nil {
+				opts.logf("%s is not a feed: %v", fullURL, err)
+			} else {
+				feed.Source = SourceScan
+			}
+			if onPath != nil {
+				onPath(feedPath, err)
+			}
+			finish(i, feed)
+		}(i, path)
 	}
 
+	wg.Wait()
+
+	return nil
+}
+
+// parseBaseURL parses the base URL of a common path scan. A URL that doesn't parse, or
+// has no scheme or host to build path URLs from, fails with ErrInvalidURL wrapping the cause.
+func parseBaseURL(baseURL string) (*url.URL, error) {
 	parsedURL, err := url.Parse(baseURL)
+	if err == nil && (parsedURL.Scheme == "" || parsedURL.Host == "") {
+		err = errors.New("missing scheme or host")
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+		return nil, fmt.Errorf("%w: base URL %q: %w", ErrInvalidURL, baseURL, err)
+	}
+	return parsedURL, nil
+}
+
+// checkFeedURLs validates each URL with checkFeedURL, running up to opts.MaxConcurrency
+// checks at a time. The result is aligned with urls, with nil for URLs that failed.
+func checkFeedURLs(ctx context.Context, urls []string, opts Options) []*Feed {
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
 	}
 
-	// Channel to control concurrency
 	semaphore := make(chan struct{}, maxConcurrency)
-	results := make(chan Feed, len(commonFeedPaths))
+	results := make([]*Feed, len(urls))
 	var wg sync.WaitGroup
 
-	// Launch goroutines for each path
-	for _, path := range commonFeedPaths {
+	for i, u := range urls {
 		wg.Add(1)
-		go func(feedPath string) {
+		go func(i int, u string) {
 			defer wg.Done()
-			semaphore <- struct{}{} // Acquire semaphore
+			semaphore <- struct{}{}        // Acquire semaphore
 			defer func() { <-semaphore }() // Release semaphore
 
-			fullURL := parsedURL.Scheme + "://" + parsedURL.Host + feedPath
-			if feed, err := checkFeedURL(fullURL); err == nil && feed != nil {
-				results <- *feed
+			if feed, err := checkFeedURL(ctx, u, opts); err == nil {
+				results[i] = feed
 			}
-		}(path)
+		}(i, u)
 	}
+	wg.Wait()
 
-	// Close results channel when all goroutines finish
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	return results
+}
 
-	// Collect results
-	var feeds []Feed
-	for feed := range results {
-		feeds = append(feeds, feed)
+// verifyFeeds checks each HTML-discovered feed with checkFeedURL and returns those that pass.
+// Verified feeds keep their advertised title and type, take the final URL after redirects,
+// and pick up the cache validators from the check.
+func verifyFeeds(ctx context.Context, feeds []Feed, opts Options) []Feed {
+	urls := make([]string, len(feeds))
+	for i, feed := range feeds {
+		urls[i] = feed.URL
 	}
 
-	return feeds, nil
+	verified := []Feed{}
+	for i, checked := range checkFeedURLs(ctx, urls, opts) {
+		if checked == nil {
+			opts.logf("dropping %s: failed verification", feeds[i].URL)
+			continue
+		}
+		feed := feeds[i]
+		feed.URL = checked.URL
+		feed.ETag = checked.ETag
+		feed.LastModified = checked.LastModified
+		if len(feed.Hubs) == 0 {
+			feed.Hubs = checked.Hubs
+		}
+		verified = append(verified, feed)
+	}
+	return verified
+}
+
+// CheckFeedURL reports whether url is a live feed, without any page discovery. It makes a
+// HEAD request, falling back to checking the body when the Content-Type is inconclusive
+// (or only a GET with PathScanGetOnly), honoring the HTTP settings in opts. Like
+// FindFeedsWithOptions, a URL without a scheme is fetched over https://.
+// It returns a nil *Feed and an error when the URL can't be fetched or isn't a feed.
+func CheckFeedURL(url string, opts Options) (*Feed, error) {
+	rawURL := url
+	url, _, err := internal.NormalizeURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidURL, rawURL, err)
+	}
+	return checkFeedURL(context.Background(), url, opts.withCookies(url))
 }
 
 // checkFeedURL checks if a URL contains a valid feed by first making a HEAD request,
-// then validating the content if it looks promising
-func checkFeedURL(url string) (*Feed, error) {
+// then validating the content if it looks promising. With PathScanGetOnly the HEAD
+// request is skipped and the content is always validated.
+// The returned feed URL is the final URL after any redirects.
+func checkFeedURL(ctx context.Context, url string, opts Options) (*Feed, error) {
+	if opts.PathScanMethod == PathScanGetOnly {
+		return validateFeedContent(ctx, url, opts)
+	}
+
 	// First, make a HEAD request to check if the URL exists and get content type
-	headResp, err := http.Head(url)
+	headResp, err := opts.doFeedRequest(ctx, http.MethodHead, url)
 	if err != nil {
 		return nil, err
 	}
 	defer headResp.Body.Close()
 
+	// Some servers don't implement HEAD, so fall back to validating with a GET
+	if headResp.StatusCode == http.StatusMethodNotAllowed || headResp.StatusCode == http.StatusNotImplemented {
+		return validateFeedContent(ctx, url, opts)
+	}
+
 	if headResp.StatusCode < 200 || headResp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HEAD request failed with status %d", headResp.StatusCode)
+		return nil, newHTTPStatusError(headResp, http.MethodHead)
 	}
 
-	contentType := strings.ToLower(headResp.Header.Get("Content-Type"))
-	
 	// Check if content type suggests it's a feed
-	var feedType string
-	if strings.Contains(contentType, "application/rss+xml") || strings.Contains(contentType, "text/xml") {
-		feedType = "rss"
-	} else if strings.Contains(contentType, "application/atom+xml") {
-		feedType = "atom"
-	} else if strings.Contains(contentType, "application/json") || strings.Contains(contentType, "application/feed+json") {
-		feedType = "json"
-	} else {
-		// If content type is not clearly a feed type, make a GET request to validate content
-		return validateFeedContent(url)
+	contentType := headResp.Header.Get("Content-Type")
+	feedType, ok := FeedTypeFromContentType(contentType)
+	if opts.Strict && !ok && !isGenericXMLContentType(contentType) {
+		return nil, fmt.Errorf("content type %q is not a feed type", contentType)
+	}
+	if !ok {
+		// If content type is not clearly a feed type, make a GET request to validate content.
+		// Generic XML could be RSS, Atom, or not a feed at all.
+		return validateFeedContent(ctx, url, opts)
 	}
 
 	return &Feed{
-		URL:   url,
-		Title: "", // We don't extract title from common path scanning
-		Type:  feedType,
+		URL:          finalURL(headResp, url),
+		Title:        "", // We don't extract title from common path scanning
+		Type:         feedType,
+		ETag:         headResp.Header.Get("ETag"),
+		LastModified: headResp.Header.Get("Last-Modified"),
 	}, nil
 }
 
-// validateFeedContent makes a GET request and validates that the content is actually a feed
-func validateFeedContent(url string) (*Feed, error) {
-	resp, err := http.Get(url)
+// isGenericXMLContentType reports whether contentType is application/xml or text/xml.
+// Many feeds are served that way, but it says nothing about which feed format, if any,
+// the document is, so such URLs are classified by fetching the body.
+func isGenericXMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == MimeTypeXML || mediaType == MimeTypeTextXML)
+}
+
+// FeedTypeFromContentType maps a Content-Type header value to a feed type ("rss", "atom",
+// or "json") and reports whether it's a recognized feed media type. Parameters such as
+// charset are ignored and media types are matched case-insensitively. Generic XML
+// (application/xml or text/xml) isn't recognized, since it could be RSS, Atom, or not
+// a feed at all.
+func FeedTypeFromContentType(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case MimeTypeRSS:
+		return "rss", true
+	case MimeTypeAtom:
+		return "atom", true
+	case MimeTypeJSON, MimeTypeFeedJSON:
+		return "json", true
+	}
+	return "", false
+}
+
+// validateFeedContent makes a GET request and validates that the content is actually a feed,
+// using opts.Validator on the first FeedHeadSize bytes of the body.
+// The returned feed URL is the final URL after any redirects.
+func validateFeedContent(ctx context.Context, url string, opts Options) (*Feed, error) {
+	resp, err := opts.doFeedRequest(ctx, http.MethodGet, url)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("GET request failed with status %d", resp.StatusCode)
+		return nil, newHTTPStatusError(resp, http.MethodGet)
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if _, ok := FeedTypeFromContentType(contentType); opts.Strict && !ok && !isGenericXMLContentType(contentType) {
+		return nil, fmt.Errorf("content type %q is not a feed type", contentType)
 	}
 
-	// Read first 1KB to check for feed indicators
-	buffer := make([]byte, 1024)
-	n, err := resp.Body.Read(buffer)
-	if err != nil && err != io.EOF {
+	// Read the start of the document for the validator
+	head, err := io.ReadAll(io.LimitReader(resp.Body, FeedHeadSize))
+	if err != nil {
 		return nil, err
 	}
 
-	content := strings.ToLower(string(buffer[:n]))
-	
-	// Check for feed format indicators in content
-	if strings.Contains(content, "<rss") || strings.Contains(content, "<rdf:rdf") {
-		return &Feed{URL: url, Title: "", Type: "rss"}, nil
+	// A JSON Feed's identifying keys can come after a long item list, and JSON can't be
+	// classified from a prefix, so JSON documents are read in full (up to MaxResponseSize)
+	if feedType, _ := FeedTypeFromContentType(contentType); feedType == "json" && len(head) == FeedHeadSize {
+		rest, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		head = append(head, rest...)
+	}
+
+	// A 2xx HTML page at a feed URL is the site's normal page served for a missing path,
+	// even if it mentions feeds or is labeled as XML
+	if isHTMLDocument(head) {
+		return nil, errors.New("content is an HTML page, not a feed")
+	}
+
+	feedType, ok := opts.validator().Validate(contentType, head)
+	if !ok {
+		return nil, errors.New("content does not appear to be a valid feed")
 	}
-	if strings.Contains(content, "<feed") && strings.Contains(content, "xmlns") {
-		return &Feed{URL: url, Title: "", Type: "atom"}, nil
+
+	url = finalURL(resp, url)
+	feed := &Feed{
+		URL:          url,
+		Title:        "",
+		Type:         feedType,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
 	}
-	if strings.Contains(content, `"version"`) && (strings.Contains(content, `"title"`) || strings.Contains(content, `"items"`)) {
-		return &Feed{URL: url, Title: "", Type: "json"}, nil
+	switch feedType {
+	case "json":
+		feed.Version, _ = jsonFeedVersion(bytes.NewReader(head))
+	case "rss", "atom":
+		_, feed.Version = xmlFeedType(bytes.NewReader(head))
+		feed.Hubs = extractFeedHubs(string(head), url)
 	}
+	return feed, nil
+}
+
+var (
+	linkElementPattern = regexp.MustCompile(`(?i)<(?:atom:)?link\b[^>]*>`)
+	attributePattern   = regexp.MustCompile(`(?i)([a-z:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
 
-	return nil, errors.New("content does not appear to be a valid feed")
+// extractFeedHubs finds WebSub hub URLs declared with <link rel="hub"> (or <atom:link rel="hub">)
+// in the beginning of an RSS or Atom document. Relative hub URLs are resolved against feedURL.
+func extractFeedHubs(content string, feedURL string) []string {
+	var hubs []string
+	for _, element := range linkElementPattern.FindAllString(content, -1) {
+		attrs := make(map[string]string)
+		for _, m := range attributePattern.FindAllStringSubmatch(element, -1) {
+			attrs[strings.ToLower(m[1])] = m[2] + m[3]
+		}
+		if strings.ToLower(attrs["rel"]) == "hub" && attrs["href"] != "" {
+			hubs = append(hubs, internal.ResolveFeedURL(attrs["href"], feedURL))
+		}
+	}
+	return hubs
 }
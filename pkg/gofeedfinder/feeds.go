@@ -0,0 +1,88 @@
+package gofeedfinder
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Feeds is a list of discovered feeds, as returned by FindFeeds.
+type Feeds []Feed
+
+// Equal reports whether f and other hold the same feeds, in any order. Feeds match when
+// their canonical URLs (see CanonicalFeedURL), types, and titles are the same; fields
+// that vary between fetches, such as ETag, are ignored.
+func (f Feeds) Equal(other Feeds) bool {
+	if len(f) != len(other) {
+		return false
+	}
+	return slices.Equal(f.sortedKeys(), other.sortedKeys())
+}
+
+// sortedKeys returns the comparison key for each feed in f, sorted.
+func (f Feeds) sortedKeys() []string {
+	keys := make([]string, len(f))
+	for i, feed := range f {
+		keys[i] = CanonicalFeedURL(feed.URL) + "\x00" + feed.Type + "\x00" + feed.Title
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// CanonicalFeedURL normalizes a feed URL for comparison: the scheme and host are
+// lower-cased, default ports, trailing slashes, and fragments are dropped. URLs that
+// can't be parsed are returned unchanged.
+func CanonicalFeedURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Hostname()
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// Valid reports whether f is a usable discovery result: its URL is absolute, with a host
+// unless it's a file:// URL, and its Type is one this package produces ("rss", "atom",
+// "json", MicroformatsFeedType, or CandidateFeedType).
+func (f Feed) Valid() bool {
+	return f.hasAbsoluteURL() && slices.Contains(validFeedTypes, f.Type)
+}
+
+// validFeedTypes are the Feed.Type values Valid accepts
+var validFeedTypes = []string{"rss", "atom", "json", MicroformatsFeedType, CandidateFeedType}
+
+// hasAbsoluteURL reports whether f.URL is absolute, as opposed to a relative href that
+// failed to resolve.
+func (f Feed) hasAbsoluteURL() bool {
+	u, err := url.Parse(f.URL)
+	return err == nil && u.IsAbs() && (u.Host != "" || u.Scheme == "file")
+}
+
+// ValidateFeeds returns the feeds in feeds that are Valid, in their original order.
+func ValidateFeeds(feeds []Feed) []Feed {
+	var valid []Feed
+	for _, feed := range feeds {
+		if feed.Valid() {
+			valid = append(valid, feed)
+		}
+	}
+	return valid
+}
+
+// validFeed reports whether FindFeedsWithOptions should return feed. With a custom
+// Validator, any non-empty Type is accepted, since the Validator may name its own formats.
+func (o Options) validFeed(feed Feed) bool {
+	if o.Validator != nil {
+		return feed.hasAbsoluteURL() && feed.Type != ""
+	}
+	return feed.Valid()
+}
@@ -0,0 +1,117 @@
+package gofeedfinder
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFeedsEqual(t *testing.T) {
+	rss := Feed{URL: "https://example.com/feed/", Title: "Posts", Type: "rss"}
+	atom := Feed{URL: "https://example.com/atom.xml", Title: "Posts", Type: "atom"}
+
+	tests := []struct {
+		name     string
+		a, b     Feeds
+		expected bool
+	}{
+		{name: "Same order", a: Feeds{rss, atom}, b: Feeds{rss, atom}, expected: true},
+		{name: "Reordered", a: Feeds{rss, atom}, b: Feeds{atom, rss}, expected: true},
+		{
+			name:     "Trailing slash and host case differ",
+			a:        Feeds{rss},
+			b:        Feeds{{URL: "https://EXAMPLE.com:443/feed", Title: "Posts", Type: "rss"}},
+			expected: true,
+		},
+		{
+			name:     "Validators are ignored",
+			a:        Feeds{rss},
+			b:        Feeds{{URL: rss.URL, Title: rss.Title, Type: rss.Type, ETag: `"v2"`}},
+			expected: true,
+		},
+		{name: "Different type", a: Feeds{rss}, b: Feeds{{URL: rss.URL, Title: rss.Title, Type: "atom"}}, expected: false},
+		{name: "Missing feed", a: Feeds{rss, atom}, b: Feeds{rss}, expected: false},
+		{name: "Duplicates count", a: Feeds{rss, rss}, b: Feeds{rss, atom}, expected: false},
+		{name: "Both empty", a: nil, b: Feeds{}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.expected {
+				t.Errorf("Equal() = %v, want %v", got, tt.expected)
+			}
+			if got := tt.b.Equal(tt.a); got != tt.expected {
+				t.Errorf("Equal() reversed = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCanonicalFeedURL(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{raw: "https://example.com/feed/", expected: "https://example.com/feed"},
+		{raw: "HTTPS://Example.COM/Feed", expected: "https://example.com/Feed"},
+		{raw: "http://example.com:80/rss.xml#top", expected: "http://example.com/rss.xml"},
+		{raw: "https://example.com:8443/?feed=rss2", expected: "https://example.com:8443?feed=rss2"},
+		{raw: "not a url", expected: "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := CanonicalFeedURL(tt.raw); got != tt.expected {
+			t.Errorf("CanonicalFeedURL(%q) = %q, want %q", tt.raw, got, tt.expected)
+		}
+	}
+}
+
+func TestFeedValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		feed     Feed
+		expected bool
+	}{
+		{name: "RSS", feed: Feed{URL: "https://example.com/rss.xml", Type: "rss"}, expected: true},
+		{name: "Atom with query", feed: Feed{URL: "https://example.com/feeds/posts/default?alt=atom", Type: "atom"}, expected: true},
+		{name: "JSON Feed", feed: Feed{URL: "http://example.com:8080/feed.json", Type: "json"}, expected: true},
+		{name: "Microformats", feed: Feed{URL: "https://example.com/", Type: MicroformatsFeedType}, expected: true},
+		{name: "Candidate", feed: Feed{URL: "https://example.com/feed", Type: CandidateFeedType}, expected: true},
+		{name: "Local file", feed: Feed{URL: "file:///tmp/site/feed.xml", Type: "rss"}, expected: true},
+		{name: "Empty URL", feed: Feed{Type: "rss"}, expected: false},
+		{name: "Relative path", feed: Feed{URL: "/feed.xml", Type: "rss"}, expected: false},
+		{name: "Relative file", feed: Feed{URL: "feed.xml", Type: "rss"}, expected: false},
+		{name: "Protocol-relative", feed: Feed{URL: "//example.com/feed", Type: "rss"}, expected: false},
+		{name: "Unparseable href", feed: Feed{URL: "/bad%zz.xml", Type: "rss"}, expected: false},
+		{name: "No host", feed: Feed{URL: "https:///feed", Type: "rss"}, expected: false},
+		{name: "Empty type", feed: Feed{URL: "https://example.com/feed"}, expected: false},
+		{name: "Media type instead of feed type", feed: Feed{URL: "https://example.com/feed", Type: "application/rss+xml"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.feed.Valid(); got != tt.expected {
+				t.Errorf("Valid() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateFeeds(t *testing.T) {
+	rss := Feed{URL: "https://example.com/rss.xml", Type: "rss"}
+	atom := Feed{URL: "https://example.com/atom.xml", Type: "atom"}
+	feeds := []Feed{
+		{URL: "/relative.xml", Type: "rss"},
+		rss,
+		{URL: "https://example.com/unknown", Type: "newsstream"},
+		atom,
+	}
+
+	expected := []Feed{rss, atom}
+	if got := ValidateFeeds(feeds); !cmp.Equal(got, expected) {
+		t.Errorf("ValidateFeeds() = %+v, want %+v", got, expected)
+	}
+	if got := ValidateFeeds(nil); got != nil {
+		t.Errorf("ValidateFeeds(nil) = %+v, want nil", got)
+	}
+}
@@ -1,26 +1,139 @@
 package internal
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
+	"path/filepath"
 	"strings"
+
+	"golang.org/x/net/idna"
 )
 
 // resolveFeedURL resolves a possibly relative feed URL (href) to an absolute URL using the given baseURL.
-// If href is already absolute, it is returned as-is. If resolution fails, the original href is returned.
+// Every href goes through url.ResolveReference, so dot segments are collapsed even in absolute URLs.
+// A protocol-relative href ("//host/path") takes the base's scheme. Query strings and fragments are
+// kept. If resolution fails, the original href is returned.
 func ResolveFeedURL(href, baseURL string) string {
-	if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+	base, baseErr := url.Parse(baseURL)
+	if baseErr != nil {
+		base = &url.URL{}
+	}
+
+	if strings.HasPrefix(href, "//") {
+		if base.Scheme == "" {
+			return href
+		}
+		href = base.Scheme + ":" + href
+	}
+
+	u, err := url.Parse(href)
+	if err != nil || (baseErr != nil && !u.IsAbs()) {
 		return href
 	}
 
-	base, err := url.Parse(baseURL)
+	resolved := base.ResolveReference(u)
+	asciiHost(resolved)
+	return resolved.String()
+}
+
+// asciiHost converts an internationalized host in u to its punycode ("xn--") form, so the
+// same site always has the same URL. Hosts that aren't valid IDNs are left as they are.
+func asciiHost(u *url.URL) {
+	hostname := u.Hostname()
+	if hostname == "" || isASCII(hostname) {
+		return
+	}
+
+	ascii, err := idna.Lookup.ToASCII(hostname)
 	if err != nil {
-		return href
+		return
+	}
+	if port := u.Port(); port != "" {
+		ascii += ":" + port
 	}
+	u.Host = ascii
+}
 
-	u, err := url.Parse(href)
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// NormalizeURL trims the raw input URL and prepends "https://" if it has no scheme.
+// Internationalized hosts are converted to punycode.
+// It reports whether a scheme was added, and returns an error if the result is not a
+// usable http or https URL with a host.
+func NormalizeURL(raw string) (string, bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false, errors.New("empty URL")
+	}
+
+	added := false
+	if !hasScheme(raw) {
+		raw = "https://" + strings.TrimPrefix(raw, "//")
+		added = true
+	}
+
+	u, err := url.Parse(raw)
 	if err != nil {
-		return href
+		return "", false, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false, fmt.Errorf("unsupported scheme %q", u.Scheme)
 	}
+	if u.Host == "" {
+		return "", false, errors.New("missing host")
+	}
+	asciiHost(u)
+
+	return u.String(), added, nil
+}
 
-	return base.ResolveReference(u).String()
+// hasScheme reports whether raw starts with a URL scheme followed by "://". A "://" later
+// on, as in a query string holding another URL, doesn't count, but a leading one does,
+// so an input with an empty scheme is rejected rather than given https://.
+func hasScheme(raw string) bool {
+	i := strings.Index(raw, "://")
+	if i < 0 {
+		return false
+	}
+	for j, c := range raw[:i] {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case j > 0 && ('0' <= c && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// LocalFilePath returns the filesystem path named by raw if it's a file:// URL or a
+// bare path (absolute, or starting with "./" or "../"). Protocol-relative URLs such
+// as "//example.com" are not paths.
+func LocalFilePath(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+
+	if strings.HasPrefix(strings.ToLower(raw), "file://") {
+		u, err := url.Parse(raw)
+		if err != nil || u.Path == "" || (u.Host != "" && u.Host != "localhost") {
+			return "", false
+		}
+		return filepath.FromSlash(u.Path), true
+	}
+
+	if strings.HasPrefix(raw, "//") || strings.Contains(raw, "://") {
+		return "", false
+	}
+	if filepath.IsAbs(raw) || strings.HasPrefix(raw, "/") || strings.HasPrefix(raw, "./") || strings.HasPrefix(raw, "../") {
+		return raw, true
+	}
+	return "", false
 }
@@ -47,6 +47,72 @@ func TestResolveFeedURL(t *testing.T) {
 			baseURL:  "https://example.com/blog/",
 			expected: "https://example.com/blog/feed.xml",
 		},
+		{
+			name:     "protocol-relative URL takes https base scheme",
+			href:     "//cdn.example.com/feed.xml",
+			baseURL:  "https://example.com/blog/",
+			expected: "https://cdn.example.com/feed.xml",
+		},
+		{
+			name:     "protocol-relative URL takes http base scheme",
+			href:     "//cdn.example.com/feed.xml?format=rss",
+			baseURL:  "http://example.com",
+			expected: "http://cdn.example.com/feed.xml?format=rss",
+		},
+		{
+			name:     "relative URL with query string",
+			href:     "/index.php?feed=rss2&lang=en",
+			baseURL:  "https://example.com/blog/",
+			expected: "https://example.com/index.php?feed=rss2&lang=en",
+		},
+		{
+			name:     "query-only URL",
+			href:     "?feed=atom",
+			baseURL:  "https://example.com/blog/",
+			expected: "https://example.com/blog/?feed=atom",
+		},
+		{
+			name:     "relative URL with query and fragment",
+			href:     "feed.xml?v=2#latest",
+			baseURL:  "https://example.com/blog/",
+			expected: "https://example.com/blog/feed.xml?v=2#latest",
+		},
+		{
+			name:     "parent-relative URL",
+			href:     "../feed.xml",
+			baseURL:  "https://example.com/blog/post/",
+			expected: "https://example.com/blog/feed.xml",
+		},
+		{
+			name:     "dot-relative URL",
+			href:     "./feed.xml",
+			baseURL:  "https://example.com/blog/post.html",
+			expected: "https://example.com/blog/feed.xml",
+		},
+		{
+			name:     "absolute URL with redundant dot segment",
+			href:     "https://example.com/blog/../feed.xml",
+			baseURL:  "https://base.com",
+			expected: "https://example.com/feed.xml",
+		},
+		{
+			name:     "absolute URL with invalid base URL",
+			href:     "https://example.com/a/./b/../feed.xml",
+			baseURL:  "://invalid-url",
+			expected: "https://example.com/a/feed.xml",
+		},
+		{
+			name:     "internationalized host",
+			href:     "https://例え.jp/feed.xml",
+			baseURL:  "https://example.com",
+			expected: "https://xn--r8jz45g.jp/feed.xml",
+		},
+		{
+			name:     "relative URL against internationalized base",
+			href:     "/feed.xml",
+			baseURL:  "https://bücher.example:8443/blog/",
+			expected: "https://xn--bcher-kva.example:8443/feed.xml",
+		},
 		{
 			name:     "invalid base URL",
 			href:     "/feed.xml",
@@ -71,3 +137,137 @@ func TestResolveFeedURL(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		expected      string
+		expectedAdded bool
+		wantErr       bool
+	}{
+		{
+			name:     "absolute https URL",
+			raw:      "https://example.com/blog",
+			expected: "https://example.com/blog",
+		},
+		{
+			name:     "absolute http URL",
+			raw:      "http://example.com",
+			expected: "http://example.com",
+		},
+		{
+			name:          "bare host",
+			raw:           "example.com",
+			expected:      "https://example.com",
+			expectedAdded: true,
+		},
+		{
+			name:          "bare host with a URL in the query",
+			raw:           "example.com/?next=http://x.com",
+			expected:      "https://example.com/?next=http://x.com",
+			expectedAdded: true,
+		},
+		{
+			name:          "bare host and path with a URL in the query",
+			raw:           "example.com/login?return=https://example.com/feed",
+			expected:      "https://example.com/login?return=https://example.com/feed",
+			expectedAdded: true,
+		},
+		{
+			name:          "bare host with path and whitespace",
+			raw:           "  example.com/blog  ",
+			expected:      "https://example.com/blog",
+			expectedAdded: true,
+		},
+		{
+			name:          "protocol-relative URL",
+			raw:           "//example.com",
+			expected:      "https://example.com",
+			expectedAdded: true,
+		},
+		{
+			name:          "internationalized host",
+			raw:           "例え.jp/feed",
+			expected:      "https://xn--r8jz45g.jp/feed",
+			expectedAdded: true,
+		},
+		{
+			name:    "empty URL",
+			raw:     "   ",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scheme",
+			raw:     "ftp://example.com",
+			wantErr: true,
+		},
+		{
+			name:    "empty scheme",
+			raw:     "://example.com",
+			wantErr: true,
+		},
+		{
+			name:    "invalid scheme",
+			raw:     "ht tp://example.com",
+			wantErr: true,
+		},
+		{
+			name:    "missing host",
+			raw:     "https:///feed.xml",
+			wantErr: true,
+		},
+		{
+			name:    "unparsable URL",
+			raw:     "https://exa mple.com",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, added, err := NormalizeURL(tc.raw)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("NormalizeURL(%q) expected error, got %q", tc.raw, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeURL(%q) unexpected error: %v", tc.raw, err)
+			}
+			if result != tc.expected || added != tc.expectedAdded {
+				t.Errorf("NormalizeURL(%q) = %q, %v, want %q, %v",
+					tc.raw, result, added, tc.expected, tc.expectedAdded)
+			}
+		})
+	}
+}
+
+func TestLocalFilePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+		ok       bool
+	}{
+		{name: "file URL", raw: "file:///tmp/site/index.html", expected: "/tmp/site/index.html", ok: true},
+		{name: "file URL with localhost", raw: "file://localhost/tmp/index.html", expected: "/tmp/index.html", ok: true},
+		{name: "file URL with remote host", raw: "file://server/share/index.html", ok: false},
+		{name: "absolute path", raw: "/tmp/index.html", expected: "/tmp/index.html", ok: true},
+		{name: "relative path", raw: "./public/index.html", expected: "./public/index.html", ok: true},
+		{name: "parent-relative path", raw: "../index.html", expected: "../index.html", ok: true},
+		{name: "protocol-relative URL", raw: "//example.com/index.html", ok: false},
+		{name: "bare host", raw: "example.com", ok: false},
+		{name: "https URL", raw: "https://example.com/index.html", ok: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, ok := LocalFilePath(tc.raw)
+			if path != tc.expected || ok != tc.ok {
+				t.Errorf("LocalFilePath(%q) = %q, %v, want %q, %v", tc.raw, path, ok, tc.expected, tc.ok)
+			}
+		})
+	}
+}
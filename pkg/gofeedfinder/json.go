@@ -0,0 +1,17 @@
+package gofeedfinder
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteJSON writes feeds to w as an indented JSON array of Feed objects, followed by a
+// newline. A nil or empty list is written as [].
+func WriteJSON(w io.Writer, feeds []Feed) error {
+	if feeds == nil {
+		feeds = []Feed{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(feeds)
+}
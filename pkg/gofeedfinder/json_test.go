@@ -0,0 +1,38 @@
+package gofeedfinder
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteJSON(t *testing.T) {
+	feeds := []Feed{
+		{URL: "https://example.com/feed.xml?a=1&b=2", Title: "News \"&\" <Updates>", Type: "rss", Source: SourceLink, Hubs: []string{"https://hub.example.com/"}},
+		{URL: "https://example.com/feed.json", Title: "", Type: "json", Source: SourceScan, Language: "en"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, feeds); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded []Feed
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("produced JSON does not parse: %v", err)
+	}
+	if !cmp.Equal(decoded, feeds) {
+		t.Errorf("decoded feeds = %+v, want %+v", decoded, feeds)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("WriteJSON(nil) = %q, want %q", got, "[]")
+	}
+}
@@ -0,0 +1,106 @@
+package gofeedfinder
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+	"golang.org/x/net/html"
+)
+
+// Link is a <link> element from the head of a page, as passed to WalkLinks.
+type Link struct {
+	Rel      string // The rel attribute, which may hold several space-separated tokens
+	Type     string // The type attribute, lowercased
+	Href     string // The href attribute as written
+	URL      string // Href resolved against the page's <base href> or base URL, or "" without an href
+	Title    string // The title attribute
+	Hreflang string // The hreflang attribute, trimmed
+	Media    string // The media attribute
+	Sizes    string // The sizes attribute, as on icons
+}
+
+// WalkLinks reads the head of the HTML in r the way ExtractFeedLinksFromStream does and
+// calls fn with every <link> element in it, in document order, not just feed links.
+// It stops early if fn returns false. Relative links are resolved against the page's
+// <base href>, or else baseURL.
+func WalkLinks(r io.Reader, baseURL string, fn func(Link) bool) error {
+	links, err := readHeadLinks(io.LimitReader(r, MaxHeadSize), baseURL)
+	if err != nil {
+		return fmt.Errorf("failed to extract head section: %w", err)
+	}
+	for _, l := range links {
+		if !fn(l) {
+			break
+		}
+	}
+	return nil
+}
+
+// readHeadLinks tokenizes the HTML in reader up to the end of its head, collecting
+// <link> and <base> elements without building a document. Relative links resolve against
+// the first <base href>, wherever in the head it appears, or else baseURL.
+func readHeadLinks(reader io.Reader, baseURL string) ([]Link, error) {
+	var links []Link
+	baseHref := ""
+
+	z := html.NewTokenizer(reader)
+tokens:
+	for {
+		switch z.Next() {
+		case html.ErrorToken:
+			if err := z.Err(); err != io.EOF {
+				return nil, err
+			}
+			break tokens
+		case html.EndTagToken:
+			if name, _ := z.TagName(); string(name) == "head" {
+				break tokens
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				break tokens
+			case "link", "base":
+				var l Link
+				for hasAttr {
+					var key, val []byte
+					key, val, hasAttr = z.TagAttr()
+					switch string(key) {
+					case "rel":
+						l.Rel = string(val)
+					case "href":
+						l.Href = string(val)
+					case "title":
+						l.Title = string(val)
+					case "type":
+						l.Type = strings.ToLower(string(val))
+					case "hreflang":
+						l.Hreflang = strings.TrimSpace(string(val))
+					case "media":
+						l.Media = string(val)
+					case "sizes":
+						l.Sizes = string(val)
+					}
+				}
+				if string(name) == "link" {
+					links = append(links, l)
+				} else if baseHref == "" {
+					baseHref = strings.TrimSpace(l.Href)
+				}
+			}
+		}
+	}
+
+	if baseHref != "" {
+		baseURL = internal.ResolveFeedURL(baseHref, baseURL)
+	}
+	for i := range links {
+		if links[i].Href != "" {
+			links[i].URL = internal.ResolveFeedURL(links[i].Href, baseURL)
+		}
+	}
+	return links, nil
+}
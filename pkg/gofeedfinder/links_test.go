@@ -0,0 +1,52 @@
+package gofeedfinder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWalkLinks(t *testing.T) {
+	html := `<!DOCTYPE html><html><head>
+		<link rel="stylesheet" href="/css/site.css" media="screen">
+		<link rel="canonical" href="https://example.com/blog/">
+		<base href="https://cdn.example.com/">
+		<link rel="alternate" type="Application/RSS+XML" href="feed.xml" title="News" hreflang=" en ">
+		<link rel="icon" href="/icon.png" sizes="32x32">
+		<link rel="preload">
+		</head><body>
+		<link rel="alternate" type="application/atom+xml" href="/body.xml">
+		</body></html>`
+
+	var links []Link
+	if err := WalkLinks(strings.NewReader(html), "https://example.com/blog/", func(l Link) bool {
+		links = append(links, l)
+		return true
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Link{
+		{Rel: "stylesheet", Href: "/css/site.css", URL: "https://cdn.example.com/css/site.css", Media: "screen"},
+		{Rel: "canonical", Href: "https://example.com/blog/", URL: "https://example.com/blog/"},
+		{Rel: "alternate", Type: "application/rss+xml", Href: "feed.xml", URL: "https://cdn.example.com/feed.xml", Title: "News", Hreflang: "en"},
+		{Rel: "icon", Href: "/icon.png", URL: "https://cdn.example.com/icon.png", Sizes: "32x32"},
+		{Rel: "preload"},
+	}
+	if !cmp.Equal(links, expected) {
+		t.Errorf("WalkLinks() links = %+v, want %+v", links, expected)
+	}
+
+	// Returning false stops the walk
+	var rels []string
+	if err := WalkLinks(strings.NewReader(html), "https://example.com/blog/", func(l Link) bool {
+		rels = append(rels, l.Rel)
+		return l.Rel != "canonical"
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(rels, []string{"stylesheet", "canonical"}) {
+		t.Errorf("WalkLinks() visited %v after stopping at canonical", rels)
+	}
+}
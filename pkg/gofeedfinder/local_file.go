@@ -0,0 +1,39 @@
+package gofeedfinder
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// findLocalFileFeeds discovers feeds in the HTML file at path, passing them to cb and
+// reporting the file's HTML to hooks.
+// Relative feed links resolve against the file's file:// URL. Common paths and sitemaps
+// are never scanned, since there's no site to scan.
+func findLocalFileFeeds(ctx context.Context, path string, opts Options, cb func(Feed), hooks discoveryHooks) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("%w %q: %v", ErrInvalidURL, path, err)
+	}
+
+	f, err := os.Open(absPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	body, err := newCharsetReader(f, "")
+	if err != nil {
+		return err
+	}
+
+	opts.logf("reading local file %s", absPath)
+	opts.ScanCommonPaths = false
+	opts.UseSitemap = false
+	baseURL := (&url.URL{Scheme: "file", Path: filepath.ToSlash(absPath)}).String()
+	c := newFeedCollector(opts, baseURL, cb)
+	c.hooks = hooks
+	return findFeedsFromReader(ctx, body, baseURL, opts, c)
+}
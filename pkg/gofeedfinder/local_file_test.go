@@ -0,0 +1,66 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindFeedsWithOptions_LocalFile(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request to %s", req.URL)
+		return nil, errors.New("unexpected request")
+	})
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.html")
+	html := `<html><head>
+		<link rel="alternate" type="application/rss+xml" href="https://example.com/feed.xml" title="Site RSS">
+		<link rel="alternate" type="application/atom+xml" href="atom.xml" title="Site Atom">
+		</head><body></body></html>`
+	if err := os.WriteFile(path, []byte(html), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Feed{
+		{URL: "https://example.com/feed.xml", Title: "Site RSS", Type: "rss", Source: SourceLink},
+		{URL: "file://" + filepath.ToSlash(dir) + "/atom.xml", Title: "Site Atom", Type: "atom", Source: SourceLink},
+	}
+
+	for _, input := range []string{path, "file://" + filepath.ToSlash(path)} {
+		feeds, err := FindFeedsWithOptions(input, Options{HTTPClient: client, ScanCommonPaths: true})
+		if err != nil {
+			t.Fatalf("FindFeedsWithOptions(%q) unexpected error: %v", input, err)
+		}
+		if !cmp.Equal(feeds, expected) {
+			t.Errorf("FindFeedsWithOptions(%q) = %+v, want %+v", input, feeds, expected)
+		}
+	}
+}
+
+func TestFindFeedsWithOptions_LocalFileNoFeeds(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("unexpected request to %s", req.URL)
+		return nil, errors.New("unexpected request")
+	})
+
+	path := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(path, []byte(`<html><head><title>No feeds</title></head></html>`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// ScanCommonPaths is ignored for local files
+	_, err := FindFeedsWithOptions(path, Options{HTTPClient: client, ScanCommonPaths: true})
+	if !errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("FindFeedsWithOptions() error = %v, want ErrNoFeedsFound", err)
+	}
+
+	_, err = FindFeedsWithOptions(filepath.Join(t.TempDir(), "missing.html"), Options{HTTPClient: client})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("FindFeedsWithOptions() error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,45 @@
+package gofeedfinder
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// extractGenericXMLLinks returns the <link rel="alternate"> elements in doc whose type is
+// generic XML (application/xml or text/xml), as feeds with an empty Type for the caller
+// to check.
+func extractGenericXMLLinks(doc *goquery.Document, pageURL string) []Feed {
+	baseURL := documentBaseURL(doc, pageURL)
+
+	var feeds []Feed
+	doc.Find("link[rel][href][type]").Each(func(i int, s *goquery.Selection) {
+		rel, _ := s.Attr("rel")
+		href, _ := s.Attr("href")
+		href = strings.TrimSpace(href)
+		linkType, _ := s.Attr("type")
+		if !hasRelToken(rel, "alternate") || href == "" || !isGenericXMLContentType(linkType) {
+			return
+		}
+
+		title, _ := s.Attr("title")
+		feeds = append(feeds, Feed{
+			URL:    internal.ResolveFeedURL(href, baseURL),
+			Title:  title,
+			Source: SourceLink,
+		})
+	})
+	return feeds
+}
+
+// findGenericXMLLinks returns the generic XML alternate links in doc that turn out to be
+// feeds when checked, typed by their content.
+func findGenericXMLLinks(ctx context.Context, doc *goquery.Document, baseURL string, opts Options) []Feed {
+	links := extractGenericXMLLinks(doc, baseURL)
+	if len(links) == 0 {
+		return nil
+	}
+	return checkLinkFeeds(ctx, links, opts, "generic XML")
+}
@@ -0,0 +1,66 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindFeedsWithOptions_LooseMIME(t *testing.T) {
+	mockHTML := `<html><head>
+		<link rel="alternate" type="application/xml" href="/feed" title="Site Feed">
+		<link rel="alternate" type="text/xml" href="/sitemap.xml">
+		<link rel="stylesheet" type="text/xml" href="/style.xml">
+		</head><body></body></html>`
+
+	var mu sync.Mutex
+	var requested []string
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requested = append(requested, req.Method+" "+req.URL.Path)
+		mu.Unlock()
+		switch req.URL.Path {
+		case "/feed":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom"><title>Site</title></feed>`)),
+				Header:     map[string][]string{"Content-Type": {"application/xml"}},
+			}, nil
+		case "/sitemap.xml":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<?xml version="1.0"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`)),
+				Header:     map[string][]string{"Content-Type": {"text/xml"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(mockHTML)),
+			Header:     map[string][]string{"Content-Type": {"text/html"}},
+		}, nil
+	})
+
+	feeds, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client, LooseMIME: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Feed{{URL: "https://example.com/feed", Title: "Site Feed", Type: "atom", Version: "1.0", Source: SourceLink}}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+	// The stylesheet isn't an alternate link, so it isn't checked
+	slices.Sort(requested)
+	if !cmp.Equal(requested, []string{"GET ", "GET /feed", "GET /sitemap.xml", "HEAD /feed", "HEAD /sitemap.xml"}) {
+		t.Errorf("requests = %v, want the page and a check of each generic XML alternate link", requested)
+	}
+
+	if _, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client}); !errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("FindFeedsWithOptions() without LooseMIME error = %v, want ErrNoFeedsFound", err)
+	}
+}
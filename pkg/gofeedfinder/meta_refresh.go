@@ -0,0 +1,99 @@
+package gofeedfinder
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// MaxMetaRefreshHops is how many <meta http-equiv="refresh"> redirects FollowMetaRefresh follows.
+const MaxMetaRefreshHops = 3
+
+// extractMetaRefreshURL returns the absolute target of the first <meta http-equiv="refresh">
+// in doc, or "" if there is none or it has no URL. Relative URLs are resolved against
+// pageURL or the document's <base href>.
+func extractMetaRefreshURL(doc *goquery.Document, pageURL string) string {
+	baseURL := documentBaseURL(doc, pageURL)
+
+	var target string
+	doc.Find("meta[http-equiv][content]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		equiv, _ := s.Attr("http-equiv")
+		if !strings.EqualFold(strings.TrimSpace(equiv), "refresh") {
+			return true
+		}
+		content, _ := s.Attr("content")
+		if href := parseMetaRefresh(content); href != "" {
+			target = internal.ResolveFeedURL(href, baseURL)
+		}
+		return false
+	})
+	return target
+}
+
+// parseMetaRefresh returns the URL in a refresh content value such as "0; url=/page",
+// or "" if it only has a delay.
+func parseMetaRefresh(content string) string {
+	i := strings.IndexAny(content, ";,")
+	if i < 0 {
+		return ""
+	}
+	rest := strings.TrimSpace(content[i+1:])
+	if len(rest) >= 3 && strings.EqualFold(rest[:3], "url") {
+		if after := strings.TrimSpace(rest[3:]); strings.HasPrefix(after, "=") {
+			rest = strings.TrimSpace(after[1:])
+		}
+	}
+	return strings.TrimSpace(strings.Trim(rest, `"'`))
+}
+
+// followMetaRefresh runs discovery on the page that page's meta refresh points to, if it
+// has one that hasn't been visited and the hop limit isn't reached. It reports whether
+// the target was fetched; if it couldn't be, discovery carries on with the original page.
+func followMetaRefresh(ctx context.Context, page *htmlPage, baseURL string, opts Options, c *feedCollector) (bool, error) {
+	target := extractMetaRefreshURL(page.document(), baseURL)
+	if target == "" || !c.visit(baseURL, target) {
+		return false, nil
+	}
+
+	opts.logf("following meta refresh from %s to %s", baseURL, target)
+	resp, err := opts.doRequest(ctx, http.MethodGet, target)
+	if err != nil {
+		opts.logf("meta refresh to %s failed: %v", target, err)
+		return false, nil
+	}
+	defer resp.Body.Close()
+	if c.hooks.onResponse != nil {
+		c.hooks.onResponse(resp)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		opts.logf("meta refresh to %s failed: %v", target, newHTTPStatusError(resp, ""))
+		return false, nil
+	}
+
+	body, err := newCharsetReader(resp.Body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return true, err
+	}
+	return true, findFeedsFromReader(ctx, body, finalURL(resp, target), opts, c)
+}
+
+// visit records a meta refresh hop from one page to another. It reports false if the
+// target was already visited or MaxMetaRefreshHops hops have been made.
+func (c *feedCollector) visit(from, to string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.visited == nil {
+		c.visited = make(map[string]bool)
+	}
+	c.visited[from] = true
+	if c.visited[to] || len(c.visited) > MaxMetaRefreshHops {
+		return false
+	}
+	c.visited[to] = true
+	return true
+}
@@ -0,0 +1,93 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractMetaRefreshURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "Relative URL",
+			html:     `<html><head><meta http-equiv="refresh" content="0; url=/real-page"></head></html>`,
+			expected: "https://example.com/real-page",
+		},
+		{
+			name:     "Quoted URL without spaces",
+			html:     `<html><head><meta http-equiv="Refresh" content="5;URL='https://other.example.com/'"></head></html>`,
+			expected: "https://other.example.com/",
+		},
+		{
+			name:     "URL without url= prefix",
+			html:     `<html><head><meta http-equiv="refresh" content="0, landing.html"></head></html>`,
+			expected: "https://example.com/landing.html",
+		},
+		{
+			name:     "Delay only",
+			html:     `<html><head><meta http-equiv="refresh" content="30"></head></html>`,
+			expected: "",
+		},
+		{
+			name:     "No refresh",
+			html:     `<html><head><meta http-equiv="content-type" content="text/html; charset=utf-8"></head></html>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractMetaRefreshURL(newHTMLPage(tt.html).document(), "https://example.com/"); got != tt.expected {
+				t.Errorf("extractMetaRefreshURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindFeedsWithOptions_FollowMetaRefresh(t *testing.T) {
+	pages := map[string]string{
+		"/":          `<html><head><meta http-equiv="refresh" content="0; url=/real-page"></head></html>`,
+		"/real-page": `<html><head><link rel="alternate" type="application/rss+xml" href="/feed.xml"></head></html>`,
+		"/loop-a":    `<html><head><meta http-equiv="refresh" content="0; url=/loop-b"></head></html>`,
+		"/loop-b":    `<html><head><meta http-equiv="refresh" content="0; url=/loop-a"></head></html>`,
+	}
+	var requests []string
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.URL.Path)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(pages[req.URL.Path])),
+			Header:     map[string][]string{"Content-Type": {"text/html"}},
+		}, nil
+	})
+
+	feeds, err := FindFeedsWithOptions("https://example.com/", Options{HTTPClient: client, FollowMetaRefresh: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Feed{{URL: "https://example.com/feed.xml", Type: "rss", Source: SourceLink}}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+
+	if _, err := FindFeedsWithOptions("https://example.com/", Options{HTTPClient: client}); !errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("FindFeedsWithOptions() without FollowMetaRefresh error = %v, want ErrNoFeedsFound", err)
+	}
+
+	// A refresh loop stops at the first page seen again
+	requests = nil
+	if _, err := FindFeedsWithOptions("https://example.com/loop-a", Options{HTTPClient: client, FollowMetaRefresh: true}); !errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("FindFeedsWithOptions() on a refresh loop error = %v, want ErrNoFeedsFound", err)
+	}
+	if !cmp.Equal(requests, []string{"/loop-a", "/loop-b"}) {
+		t.Errorf("requests on a refresh loop = %v, want each page once", requests)
+	}
+}
@@ -0,0 +1,30 @@
+package gofeedfinder
+
+import "time"
+
+// Metrics holds optional callbacks that observe each HTTP request discovery makes, for
+// building dashboards of discovery performance without tying the package to a metrics
+// library. Unset callbacks are skipped. Requests run concurrently during path scans and
+// feed checks, so the callbacks must be safe for concurrent use.
+type Metrics struct {
+	// OnRequest is called as each request is sent, including each retry of a 429.
+	OnRequest func(method, url string)
+
+	// OnResponse is called when each request completes, with the response status (0 if
+	// the request failed without a response) and how long it took, redirects included.
+	OnResponse func(url string, status int, dur time.Duration)
+}
+
+// onRequest calls m.OnRequest, if m and it are set.
+func (m *Metrics) onRequest(method, url string) {
+	if m != nil && m.OnRequest != nil {
+		m.OnRequest(method, url)
+	}
+}
+
+// onResponse calls m.OnResponse, if m and it are set.
+func (m *Metrics) onResponse(url string, status int, dur time.Duration) {
+	if m != nil && m.OnResponse != nil {
+		m.OnResponse(url, status, dur)
+	}
+}
@@ -0,0 +1,77 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMetrics(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<html><head><link rel="alternate" type="application/rss+xml" href="/feed.xml"></head></html>`)),
+				Header:     make(http.Header),
+			}, nil
+		case "/feed.xml":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       http.NoBody,
+				Header:     map[string][]string{"Content-Type": {"application/rss+xml"}},
+			}, nil
+		}
+		return nil, errors.New("connection refused")
+	})
+
+	var mu sync.Mutex
+	var requests, responses []string
+	metrics := &Metrics{
+		OnRequest: func(method, url string) {
+			mu.Lock()
+			defer mu.Unlock()
+			requests = append(requests, method+" "+url)
+		},
+		OnResponse: func(url string, status int, dur time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			if dur < 0 {
+				t.Errorf("OnResponse(%s) duration = %v, want >= 0", url, dur)
+			}
+			responses = append(responses, url+" "+http.StatusText(status))
+		},
+	}
+	opts := Options{HTTPClient: client, VerifyFeeds: true, Metrics: metrics}
+
+	if _, err := FindFeedsWithOptions("https://example.com", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := CheckFeedURL("https://example.com/missing", opts); err == nil {
+		t.Fatal("CheckFeedURL() expected an error")
+	}
+
+	expectedRequests := []string{
+		"GET https://example.com",
+		"HEAD https://example.com/feed.xml",
+		"HEAD https://example.com/missing",
+	}
+	if !cmp.Equal(requests, expectedRequests) {
+		t.Errorf("OnRequest calls = %v, want %v", requests, expectedRequests)
+	}
+	// A failed request is reported with status 0, which has no status text
+	expectedResponses := []string{
+		"https://example.com OK",
+		"https://example.com/feed.xml OK",
+		"https://example.com/missing ",
+	}
+	if !cmp.Equal(responses, expectedResponses) {
+		t.Errorf("OnResponse calls = %v, want %v", responses, expectedResponses)
+	}
+}
@@ -0,0 +1,31 @@
+package gofeedfinder
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MicroformatsFeedType is the Feed.Type of pages discovered through h-feed markup
+const MicroformatsFeedType = "microformats"
+
+// findMicroformatsFeed reports whether doc contains an h-feed with at least one h-entry,
+// and if so returns a Feed for the page itself. The title is the h-feed's p-name, if any.
+func findMicroformatsFeed(doc *goquery.Document, pageURL string) (Feed, bool) {
+	var feed Feed
+	found := false
+	doc.Find(".h-feed").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		if s.Find(".h-entry").Length() == 0 {
+			return true
+		}
+		// The feed's own name, not one belonging to an entry
+		name := s.Find(".p-name").FilterFunction(func(i int, n *goquery.Selection) bool {
+			return n.ParentsFiltered(".h-entry").Length() == 0
+		}).First()
+		feed = Feed{URL: pageURL, Title: strings.TrimSpace(name.Text()), Type: MicroformatsFeedType, Source: SourceLink}
+		found = true
+		return false
+	})
+
+	return feed, found
+}
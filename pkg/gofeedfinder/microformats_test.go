@@ -0,0 +1,75 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindMicroformatsFeed(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected Feed
+		found    bool
+	}{
+		{
+			name: "h-feed with entries and a name",
+			html: `<html><body><div class="h-feed"><h1 class="p-name">Notes</h1>
+				<article class="h-entry"><h2 class="p-name">First post</h2></article>
+				<article class="h-entry"><h2 class="p-name">Second post</h2></article>
+				</div></body></html>`,
+			expected: Feed{URL: "https://example.com/notes", Title: "Notes", Type: "microformats", Source: SourceLink},
+			found:    true,
+		},
+		{
+			name:     "h-feed without a name",
+			html:     `<html><body><main class="h-feed"><article class="h-entry"><p class="p-name">Hi</p></article></main></body></html>`,
+			expected: Feed{URL: "https://example.com/notes", Title: "", Type: "microformats", Source: SourceLink},
+			found:    true,
+		},
+		{
+			name:  "h-feed without entries",
+			html:  `<html><body><div class="h-feed"><h1 class="p-name">Empty</h1></div></body></html>`,
+			found: false,
+		},
+		{
+			name:  "no microformats",
+			html:  `<html><body><article><h2>Post</h2></article></body></html>`,
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feed, found := findMicroformatsFeed(newHTMLPage(tt.html).document(), "https://example.com/notes")
+			if found != tt.found || !cmp.Equal(feed, tt.expected) {
+				t.Errorf("findMicroformatsFeed() = %+v, %v, want %+v, %v", feed, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestFindFeedsFromReader_Microformats(t *testing.T) {
+	html := `<html><head><title>Notes</title></head><body>
+		<div class="h-feed"><h1 class="p-name">My Notes</h1>
+		<article class="h-entry"><p class="p-name e-content">Hello</p></article>
+		</div></body></html>`
+
+	feeds, err := FindFeedsFromReader(strings.NewReader(html), "https://example.com/notes", Options{Microformats: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Feed{{URL: "https://example.com/notes", Title: "My Notes", Type: "microformats", Source: SourceLink}}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("FindFeedsFromReader() = %+v, want %+v", feeds, expected)
+	}
+
+	// The fallback is opt-in
+	_, err = FindFeedsFromReader(strings.NewReader(html), "https://example.com/notes", Options{})
+	if !errors.Is(err, ErrNoFeedsFound) {
+		t.Errorf("FindFeedsFromReader() without Microformats error = %v, want ErrNoFeedsFound", err)
+	}
+}
@@ -0,0 +1,52 @@
+package gofeedfinder
+
+import "sync"
+
+// DefaultSiteConcurrency is the number of pages FindFeedsForURLs discovers at once
+// when Options.SiteConcurrency is unset.
+const DefaultSiteConcurrency = 4
+
+// FindFeedsForURLs runs FindFeedsWithOptions on each of urls concurrently. Results and
+// errors are keyed by the input URL; each URL appears in exactly one of the two maps.
+// At most opts.SiteConcurrency pages are processed at once, so the number of requests
+// in flight stays below SiteConcurrency * MaxConcurrency.
+func FindFeedsForURLs(urls []string, opts Options) (map[string][]Feed, map[string]error) {
+	siteConcurrency := opts.SiteConcurrency
+	if siteConcurrency <= 0 {
+		siteConcurrency = DefaultSiteConcurrency
+	}
+
+	results := make(map[string][]Feed)
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, siteConcurrency)
+	seen := make(map[string]bool)
+
+	for _, u := range urls {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+
+		wg.Add(1)
+		go func(u string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			feeds, err := FindFeedsWithOptions(u, opts)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[u] = err
+				return
+			}
+			results[u] = feeds
+		}(u)
+	}
+
+	wg.Wait()
+	return results, errs
+}
@@ -0,0 +1,60 @@
+package gofeedfinder
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindFeedsForURLs(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		if req.URL.Host == "down.example.com" {
+			return nil, errors.New("mock network error")
+		}
+		html := `<html><head><link rel="alternate" type="application/rss+xml" href="/feed.xml" title="Feed"></head></html>`
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(html)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	urls := []string{"https://a.example.com", "https://down.example.com", "https://b.example.com"}
+	results, errs := FindFeedsForURLs(urls, Options{HTTPClient: client, SiteConcurrency: 2})
+
+	expected := map[string][]Feed{
+		"https://a.example.com": {{URL: "https://a.example.com/feed.xml", Title: "Feed", Type: "rss", Source: SourceLink}},
+		"https://b.example.com": {{URL: "https://b.example.com/feed.xml", Title: "Feed", Type: "rss", Source: SourceLink}},
+	}
+	if !cmp.Equal(results, expected) {
+		t.Errorf("FindFeedsForURLs() results = %+v, want %+v", results, expected)
+	}
+
+	if len(errs) != 1 || errs["https://down.example.com"] == nil {
+		t.Errorf("FindFeedsForURLs() errors = %v, want a single error for https://down.example.com", errs)
+	}
+
+	if maxInFlight > 2 {
+		t.Errorf("saw %d concurrent requests, want at most SiteConcurrency (2)", maxInFlight)
+	}
+}
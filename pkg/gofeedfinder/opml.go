@@ -0,0 +1,102 @@
+package gofeedfinder
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// OPML is an OPML 2.0 document listing feed subscriptions.
+type OPML struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    OPMLHead `xml:"head"`
+	Body    OPMLBody `xml:"body"`
+}
+
+// OPMLHead holds the document metadata.
+type OPMLHead struct {
+	Title string `xml:"title"`
+}
+
+// OPMLBody holds the document outlines.
+type OPMLBody struct {
+	Outlines []OPMLOutline `xml:"outline"`
+}
+
+// OPMLOutline is a single feed subscription, or a group of nested outlines.
+type OPMLOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr"`
+	XMLURL   string        `xml:"xmlUrl,attr"`
+	Outlines []OPMLOutline `xml:"outline,omitempty"`
+}
+
+// NewOPML builds an OPML 2.0 document with the given title and one outline per feed.
+// Feeds without a title use their URL as the outline text.
+func NewOPML(title string, feeds []Feed) OPML {
+	doc := OPML{
+		Version: "2.0",
+		Head:    OPMLHead{Title: title},
+	}
+
+	for _, feed := range feeds {
+		text := feed.Title
+		if text == "" {
+			text = feed.URL
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, OPMLOutline{
+			Text:   text,
+			Title:  feed.Title,
+			Type:   feed.Type,
+			XMLURL: feed.URL,
+		})
+	}
+
+	return doc
+}
+
+// WriteOPML writes feeds to w as an indented OPML 2.0 document built by NewOPML, with an
+// XML declaration and a trailing newline.
+func WriteOPML(w io.Writer, feeds []Feed, title string) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(NewOPML(title, feeds)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ParseOPML reads the feed subscriptions in an OPML document, such as a feed reader's export,
+// so they can be re-checked with CheckFeedURL. Outlines nested in groups are included;
+// outlines without an xmlUrl are skipped. The title is the outline's title, or its text
+// unless that's just the URL.
+func ParseOPML(r io.Reader) ([]Feed, error) {
+	var doc OPML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid OPML: %w", err)
+	}
+
+	feeds := []Feed{}
+	var walk func(outlines []OPMLOutline)
+	walk = func(outlines []OPMLOutline) {
+		for _, outline := range outlines {
+			if outline.XMLURL != "" {
+				title := outline.Title
+				if title == "" && outline.Text != outline.XMLURL {
+					title = outline.Text
+				}
+				feeds = append(feeds, Feed{URL: outline.XMLURL, Title: title, Type: outline.Type})
+			}
+			walk(outline.Outlines)
+		}
+	}
+	walk(doc.Body.Outlines)
+
+	return feeds, nil
+}
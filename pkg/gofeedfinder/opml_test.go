@@ -0,0 +1,105 @@
+package gofeedfinder
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewOPML(t *testing.T) {
+	feeds := []Feed{
+		{URL: "https://example.com/feed.xml", Title: "Example RSS", Type: "rss"},
+		{URL: "https://example.com/atom.xml", Title: "", Type: "atom"},
+	}
+
+	out, err := xml.Marshal(NewOPML("https://example.com", feeds))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc OPML
+	if err := xml.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("produced OPML does not unmarshal: %v", err)
+	}
+
+	if doc.Version != "2.0" {
+		t.Errorf("Version = %q, want %q", doc.Version, "2.0")
+	}
+	if doc.Head.Title != "https://example.com" {
+		t.Errorf("Head.Title = %q, want %q", doc.Head.Title, "https://example.com")
+	}
+
+	expected := []OPMLOutline{
+		{Text: "Example RSS", Title: "Example RSS", Type: "rss", XMLURL: "https://example.com/feed.xml"},
+		{Text: "https://example.com/atom.xml", Title: "", Type: "atom", XMLURL: "https://example.com/atom.xml"},
+	}
+	if !cmp.Equal(doc.Body.Outlines, expected) {
+		t.Errorf("Outlines = %+v, want %+v", doc.Body.Outlines, expected)
+	}
+}
+
+func TestWriteOPML(t *testing.T) {
+	feeds := []Feed{
+		{URL: "https://example.com/feed.xml?a=1&b=2", Title: "News \"&\" <Updates>", Type: "rss"},
+		{URL: "https://example.com/atom.xml", Title: "", Type: "atom"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOPML(&buf, feeds, "Tom & Jerry's <Feeds>"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header) {
+		t.Errorf("WriteOPML() output does not start with the XML declaration: %q", buf.String())
+	}
+
+	var doc OPML
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("produced OPML does not unmarshal: %v", err)
+	}
+	if doc.Head.Title != "Tom & Jerry's <Feeds>" {
+		t.Errorf("Head.Title = %q, want %q", doc.Head.Title, "Tom & Jerry's <Feeds>")
+	}
+
+	parsed, err := ParseOPML(&buf)
+	if err != nil {
+		t.Fatalf("ParseOPML() unexpected error: %v", err)
+	}
+	if !cmp.Equal(parsed, feeds) {
+		t.Errorf("ParseOPML() = %+v, want %+v", parsed, feeds)
+	}
+}
+
+func TestParseOPML(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head><title>Subscriptions</title></head>
+  <body>
+    <outline text="Example RSS" type="rss" xmlUrl="https://example.com/feed.xml"/>
+    <outline text="Tech" title="Tech">
+      <outline text="Go Blog" title="The Go Blog" type="rss" xmlUrl="https://go.dev/blog/feed.atom"/>
+      <outline text="https://example.org/atom.xml" type="atom" xmlUrl="https://example.org/atom.xml"/>
+    </outline>
+  </body>
+</opml>`
+
+	feeds, err := ParseOPML(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []Feed{
+		{URL: "https://example.com/feed.xml", Title: "Example RSS", Type: "rss"},
+		{URL: "https://go.dev/blog/feed.atom", Title: "The Go Blog", Type: "rss"},
+		{URL: "https://example.org/atom.xml", Title: "", Type: "atom"},
+	}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("ParseOPML() = %+v, want %+v", feeds, expected)
+	}
+
+	if _, err := ParseOPML(strings.NewReader(`<html><body></body></html>`)); err == nil {
+		t.Error("ParseOPML() expected an error for a non-OPML document")
+	}
+}
@@ -0,0 +1,44 @@
+package gofeedfinder
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+	"golang.org/x/net/html"
+)
+
+// htmlPage is the HTML of a page being searched for feeds. It's parsed on first use, so
+// the fallbacks that look past the page's feed <link> tags share a single parse.
+type htmlPage struct {
+	html string
+	doc  *goquery.Document
+}
+
+// newHTMLPage returns an htmlPage for html, which isn't parsed until it's needed.
+func newHTMLPage(html string) *htmlPage {
+	return &htmlPage{html: html}
+}
+
+// document returns the page parsed with goquery.
+func (p *htmlPage) document() *goquery.Document {
+	if p.doc == nil {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(p.html))
+		if err != nil {
+			// Reading a string can't fail, but an empty document keeps the fallbacks safe
+			doc = goquery.NewDocumentFromNode(&html.Node{Type: html.DocumentNode})
+		}
+		p.doc = doc
+	}
+	return p.doc
+}
+
+// documentBaseURL returns the URL relative links in doc resolve against. Per the HTML
+// spec, the first <base href> sets it, itself resolved against pageURL; without one,
+// it's pageURL.
+func documentBaseURL(doc *goquery.Document, pageURL string) string {
+	if baseHref, ok := doc.Find("base[href]").First().Attr("href"); ok && strings.TrimSpace(baseHref) != "" {
+		return internal.ResolveFeedURL(strings.TrimSpace(baseHref), pageURL)
+	}
+	return pageURL
+}
@@ -0,0 +1,41 @@
+package gofeedfinder
+
+import "testing"
+
+func TestDocumentBaseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{name: "No base href", html: `<html><head></head></html>`, expected: "https://example.com/blog/post"},
+		{name: "Absolute base href", html: `<head><base href="https://cdn.example.net/site/"></head>`, expected: "https://cdn.example.net/site/"},
+		{name: "Relative base href", html: `<head><base href="/other/"></head>`, expected: "https://example.com/other/"},
+		{name: "First base wins", html: `<head><base href="/first/"><base href="/second/"></head>`, expected: "https://example.com/first/"},
+		{name: "Blank base href", html: `<head><base href="  "></head>`, expected: "https://example.com/blog/post"},
+		{name: "Base without href", html: `<head><base target="_blank"><base href="/later/"></head>`, expected: "https://example.com/later/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := newHTMLPage(tt.html).document()
+			if got := documentBaseURL(doc, "https://example.com/blog/post"); got != tt.expected {
+				t.Errorf("documentBaseURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHTMLPage_ParsesOnce(t *testing.T) {
+	page := newHTMLPage(`<html><body><a href="/feed.xml">RSS</a></body></html>`)
+	if page.doc != nil {
+		t.Fatal("page parsed before first use")
+	}
+	doc := page.document()
+	if doc.Find("a").Length() != 1 {
+		t.Errorf("document() has %d anchors, want 1", doc.Find("a").Length())
+	}
+	if page.document() != doc {
+		t.Error("document() parsed the page again")
+	}
+}
@@ -0,0 +1,128 @@
+package gofeedfinder
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// adaptiveFastResponse is how quickly a successful check must complete for an adaptive
+// scan to raise its concurrency.
+const adaptiveFastResponse = 500 * time.Millisecond
+
+// hostThrottle spaces out requests to each host by a minimum delay.
+// It is safe for concurrent use.
+type hostThrottle struct {
+	mu    sync.Mutex
+	delay time.Duration
+	next  map[string]time.Time // Earliest time the next request to each host may start
+	now   func() time.Time     // The clock slots are reserved by; time.Now outside tests
+}
+
+// newHostThrottle returns a hostThrottle enforcing delay between requests to the same host.
+// A zero delay disables throttling.
+func newHostThrottle(delay time.Duration) *hostThrottle {
+	return &hostThrottle{
+		delay: delay,
+		next:  make(map[string]time.Time),
+		now:   time.Now,
+	}
+}
+
+// wait blocks until a request to host may start, reserving the following slot for the
+// next caller. It returns early with the context's error if ctx is cancelled.
+func (t *hostThrottle) wait(ctx context.Context, host string) error {
+	if t.delay <= 0 {
+		return ctx.Err()
+	}
+
+	slot := t.reserve(host)
+	timer := time.NewTimer(slot.Sub(t.now()))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve returns the time the caller's request to host may start, the earliest free
+// slot that isn't in the past, and books the slot delay after it for the next caller.
+func (t *hostThrottle) reserve(host string) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.now()
+	slot := t.next[host]
+	if slot.Before(now) {
+		slot = now
+	}
+	t.next[host] = slot.Add(t.delay)
+	return slot
+}
+
+// concurrencyLimiter bounds the number of checks in flight. With a fixed limit it behaves
+// like a semaphore. An adaptive limiter starts at one and doubles its limit, up to max,
+// after each fast success, and halves it after a 429 or a timeout.
+type concurrencyLimiter struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	limit    int
+	max      int
+	inFlight int
+	adaptive bool
+}
+
+// newConcurrencyLimiter returns a concurrencyLimiter allowing up to max checks at once.
+func newConcurrencyLimiter(max int, adaptive bool) *concurrencyLimiter {
+	l := &concurrencyLimiter{limit: max, max: max, adaptive: adaptive}
+	if adaptive {
+		l.limit = 1
+	}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire blocks until another check may start.
+func (l *concurrencyLimiter) acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.inFlight >= l.limit {
+		l.cond.Wait()
+	}
+	l.inFlight++
+}
+
+// release marks a check as finished, adjusting an adaptive limit by its outcome: err is
+// the check's error and elapsed how long it took.
+func (l *concurrencyLimiter) release(err error, elapsed time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.inFlight--
+
+	if l.adaptive {
+		switch {
+		case isBackoffError(err):
+			l.limit = max(1, l.limit/2)
+		case elapsed < adaptiveFastResponse:
+			l.limit = min(l.max, l.limit*2)
+		}
+	}
+	l.cond.Broadcast()
+}
+
+// isBackoffError reports whether err suggests the server is overloaded or rate limiting us.
+func isBackoffError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var statusErr *HTTPStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode == http.StatusServiceUnavailable
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
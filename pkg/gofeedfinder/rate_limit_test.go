@@ -0,0 +1,149 @@
+package gofeedfinder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHostThrottle(t *testing.T) {
+	delay := 20 * time.Millisecond
+	throttle := newHostThrottle(delay)
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttle.now = func() time.Time { return clock }
+
+	// Requests arriving together get consecutive slots, delay apart
+	for i := 0; i < 4; i++ {
+		if slot, want := throttle.reserve("example.com"), clock.Add(time.Duration(i)*delay); !slot.Equal(want) {
+			t.Errorf("slot %d = %v, want %v", i, slot, want)
+		}
+	}
+
+	// Other hosts are not held up
+	if slot := throttle.reserve("example.org"); !slot.Equal(clock) {
+		t.Errorf("first slot for another host = %v, want %v", slot, clock)
+	}
+
+	// Once the booked slots have passed, the next request may start right away
+	clock = clock.Add(10 * delay)
+	if slot := throttle.reserve("example.com"); !slot.Equal(clock) {
+		t.Errorf("slot after idle period = %v, want %v", slot, clock)
+	}
+
+	// A reserved slot in the past doesn't block, and a cancelled wait returns the context's error
+	if err := throttle.wait(context.Background(), "example.net"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	throttle.next["example.net"] = clock.Add(time.Hour)
+	if err := throttle.wait(ctx, "example.net"); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait() with cancelled context = %v, want context.Canceled", err)
+	}
+}
+
+func TestScanCommonFeedPaths_RequestDelay(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	var last time.Duration
+	start := time.Now()
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests++
+		last = max(last, time.Since(start))
+		mu.Unlock()
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	delay := 10 * time.Millisecond
+	opts := Options{HTTPClient: client, MaxConcurrency: 5, RequestDelay: delay}
+	if _, err := scanCommonFeedPaths("https://example.com", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != len(commonFeedPaths) {
+		t.Fatalf("expected %d requests, got %d", len(commonFeedPaths), requests)
+	}
+	// Timers never fire early, so the last of the throttled slots can't start sooner than
+	// this, however the scheduler spaces out the individual requests
+	if want := time.Duration(requests-1) * delay; last < want {
+		t.Errorf("last request started after %v, want at least %v", last, want)
+	}
+}
+
+func TestConcurrencyLimiter_Adaptive(t *testing.T) {
+	l := newConcurrencyLimiter(8, true)
+	if l.limit != 1 {
+		t.Fatalf("initial limit = %d, want 1", l.limit)
+	}
+
+	// Fast responses double the limit up to the maximum
+	for _, want := range []int{2, 4, 8, 8} {
+		l.acquire()
+		l.release(nil, time.Millisecond)
+		if l.limit != want {
+			t.Errorf("limit after fast response = %d, want %d", l.limit, want)
+		}
+	}
+
+	// Rate limiting and timeouts halve it, down to one
+	backoffErrors := []error{
+		&HTTPStatusError{StatusCode: http.StatusTooManyRequests, Method: http.MethodHead},
+		context.DeadlineExceeded,
+		&HTTPStatusError{StatusCode: http.StatusServiceUnavailable},
+		&HTTPStatusError{StatusCode: http.StatusTooManyRequests},
+	}
+	for i, want := range []int{4, 2, 1, 1} {
+		l.acquire()
+		l.release(backoffErrors[i], time.Millisecond)
+		if l.limit != want {
+			t.Errorf("limit after %v = %d, want %d", backoffErrors[i], l.limit, want)
+		}
+	}
+
+	// Slow responses leave it unchanged
+	l.acquire()
+	l.release(errors.New("not a feed"), time.Second)
+	if l.limit != 1 {
+		t.Errorf("limit after slow response = %d, want 1", l.limit)
+	}
+}
+
+func TestScanCommonFeedPaths_AdaptiveConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Body:       io.NopCloser(strings.NewReader("Too Many Requests")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	// Every response is a 429, so the adaptive scan never leaves one request at a time
+	opts := Options{HTTPClient: client, MaxConcurrency: 5, AdaptiveConcurrency: true}
+	if _, err := scanCommonFeedPaths("https://example.com", opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if maxInFlight != 1 {
+		t.Errorf("saw %d concurrent requests under 429s, want 1", maxInFlight)
+	}
+}
@@ -0,0 +1,97 @@
+package gofeedfinder
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// relFeedExtensions maps href extensions that identify a rel="feed" link's feed type
+var relFeedExtensions = map[string]string{
+	".rss":  "rss",
+	".atom": "atom",
+}
+
+// extractRelFeedLinks returns the <link rel="feed"> elements in doc as feeds. The type
+// comes from the type attribute or else the href extension; links whose type can't be
+// inferred, including those typed as generic XML, are returned with an empty Type for
+// the caller to validate.
+func extractRelFeedLinks(doc *goquery.Document, pageURL string) []Feed {
+	baseURL := documentBaseURL(doc, pageURL)
+
+	var feeds []Feed
+	doc.Find("link[rel][href]").Each(func(i int, s *goquery.Selection) {
+		rel, _ := s.Attr("rel")
+		href, _ := s.Attr("href")
+		href = strings.TrimSpace(href)
+		if !hasRelToken(rel, "feed") || href == "" {
+			return
+		}
+
+		// Generic XML says nothing about the format, so the link's content decides
+		linkType, _ := s.Attr("type")
+		feedType, ok := FeedTypeFromContentType(linkType)
+		if isGenericXMLContentType(linkType) {
+			feedType = ""
+		} else if !ok {
+			hrefPath := strings.ToLower(href)
+			if i := strings.IndexAny(hrefPath, "?#"); i >= 0 {
+				hrefPath = hrefPath[:i]
+			}
+			feedType = relFeedExtensions[path.Ext(hrefPath)]
+		}
+
+		title, _ := s.Attr("title")
+		feeds = append(feeds, Feed{
+			URL:    internal.ResolveFeedURL(href, baseURL),
+			Title:  title,
+			Type:   feedType,
+			Source: SourceLink,
+		})
+	})
+	return feeds
+}
+
+// findRelFeedLinks returns the rel="feed" links in doc, checking with checkFeedURL
+// those whose type couldn't be inferred and dropping the ones that aren't feeds.
+func findRelFeedLinks(ctx context.Context, doc *goquery.Document, baseURL string, opts Options) []Feed {
+	var feeds, ambiguous []Feed
+	for _, feed := range extractRelFeedLinks(doc, baseURL) {
+		if feed.Type == "" {
+			ambiguous = append(ambiguous, feed)
+		} else {
+			feeds = append(feeds, feed)
+		}
+	}
+	if len(ambiguous) == 0 {
+		return feeds
+	}
+	return append(feeds, checkLinkFeeds(ctx, ambiguous, opts, `rel="feed"`)...)
+}
+
+// checkLinkFeeds checks links of unknown type with checkFeedURL, returning those that are
+// feeds, typed by the check and keeping their advertised titles. The label names the kind
+// of link in log messages.
+func checkLinkFeeds(ctx context.Context, links []Feed, opts Options, label string) []Feed {
+	urls := make([]string, len(links))
+	for i, feed := range links {
+		urls[i] = feed.URL
+	}
+
+	var feeds []Feed
+	for i, checked := range checkFeedURLs(ctx, urls, opts) {
+		if checked == nil {
+			opts.logf("dropping %s link %s: not a feed", label, urls[i])
+			continue
+		}
+		if links[i].Title != "" {
+			checked.Title = links[i].Title
+		}
+		checked.Source = SourceLink
+		feeds = append(feeds, *checked)
+	}
+	return feeds
+}
@@ -0,0 +1,99 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractRelFeedLinks(t *testing.T) {
+	html := `<html><head>
+		<link rel="feed" type="application/atom+xml" href="/atom" title="Atom">
+		<link rel="feed" href="/posts.rss">
+		<link rel="feed" href="/updates">
+		<link rel="feed" type="text/xml" href="/changes.rss">
+		<link rel="feed" type="application/xml" href="/news.xml">
+		<link rel="alternate" type="application/rss+xml" href="/rss.xml">
+	</head></html>`
+
+	expected := []Feed{
+		{URL: "https://example.com/atom", Title: "Atom", Type: "atom", Source: SourceLink},
+		{URL: "https://example.com/posts.rss", Type: "rss", Source: SourceLink},
+		{URL: "https://example.com/updates", Type: "", Source: SourceLink},
+		{URL: "https://example.com/changes.rss", Type: "", Source: SourceLink},
+		{URL: "https://example.com/news.xml", Type: "", Source: SourceLink},
+	}
+	if got := extractRelFeedLinks(newHTMLPage(html).document(), "https://example.com"); !cmp.Equal(got, expected) {
+		t.Errorf("extractRelFeedLinks() = %+v, want %+v", got, expected)
+	}
+}
+
+func TestFindFeedsWithOptions_RelFeed(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/updates", "/changes":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`)),
+				Header:     map[string][]string{"Content-Type": {"application/atom+xml"}},
+			}, nil
+		case "/about":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<html></html>`)),
+				Header:     map[string][]string{"Content-Type": {"text/html"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(`<html><head>
+				<link rel="feed" type="application/rss+xml" href="/rss.xml" title="Posts">
+				<link rel="feed" href="/updates" title="Updates">
+				<link rel="feed" type="text/xml" href="/changes" title="Changes">
+				<link rel="feed" type="text/xml" href="/sitemap.xml">
+				<link rel="feed" href="/about">
+			</head><body></body></html>`)),
+			Header: map[string][]string{"Content-Type": {"text/html"}},
+		}, nil
+	})
+
+	tests := []struct {
+		name     string
+		relFeed  bool
+		expected []Feed
+	}{
+		{
+			name:    "Enabled",
+			relFeed: true,
+			expected: []Feed{
+				{URL: "https://example.com/rss.xml", Title: "Posts", Type: "rss", Source: SourceLink},
+				{URL: "https://example.com/updates", Title: "Updates", Type: "atom", Source: SourceLink},
+				{URL: "https://example.com/changes", Title: "Changes", Type: "atom", Source: SourceLink},
+			},
+		},
+		{
+			name: "Disabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeds, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client, RelFeed: tt.relFeed})
+			if tt.expected == nil {
+				if err == nil {
+					t.Errorf("expected ErrNoFeedsFound without RelFeed, got %+v", feeds)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(feeds, tt.expected) {
+				t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package gofeedfinder
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryAfter is how long to wait before retrying a 429 response that has no
+// usable Retry-After header.
+const DefaultRetryAfter = time.Second
+
+// parseRetryAfter parses a Retry-After header value, given either as a number of seconds
+// or as an HTTP date, into a wait relative to now. Dates in the past yield zero.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(0, date.Sub(now)), true
+}
+
+// waitRetryAfter sleeps for the wait requested by a Retry-After header value, or
+// DefaultRetryAfter if it can't be parsed. It returns the context's error if ctx is
+// done first, or if ctx's deadline would pass before the wait is over.
+func waitRetryAfter(ctx context.Context, retryAfter string, opts Options) error {
+	wait, ok := parseRetryAfter(retryAfter, time.Now())
+	if !ok {
+		wait = DefaultRetryAfter
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		return context.DeadlineExceeded
+	}
+
+	opts.logf("rate limited, retrying in %v", wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,116 @@
+package gofeedfinder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 10, 21, 7, 28, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "Seconds", value: "120", expected: 2 * time.Minute, ok: true},
+		{name: "Zero seconds", value: "0", expected: 0, ok: true},
+		{name: "HTTP date", value: "Tue, 21 Oct 2025 07:28:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "HTTP date in the past", value: "Tue, 21 Oct 2025 07:00:00 GMT", expected: 0, ok: true},
+		{name: "RFC 850 date", value: "Tuesday, 21-Oct-25 07:29:00 GMT", expected: time.Minute, ok: true},
+		{name: "Negative seconds", value: "-5", ok: false},
+		{name: "Empty", value: "", ok: false},
+		{name: "Garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(tt.value, now)
+			if wait != tt.expected || ok != tt.ok {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tt.value, wait, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDoRequest_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+	}{
+		{name: "Seconds", retryAfter: "0"},
+		{name: "HTTP date", retryAfter: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			client := testClient(func(req *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts == 1 {
+					return &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Body:       io.NopCloser(strings.NewReader("slow down")),
+						Header:     http.Header{"Retry-After": {tt.retryAfter}},
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(strings.NewReader(`<rss version="2.0"></rss>`)),
+					Header:     make(http.Header),
+				}, nil
+			})
+
+			resp, err := Options{HTTPClient: client, MaxRetries: 1}.doRequest(context.Background(), http.MethodGet, "https://example.com/feed")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != 200 || attempts != 2 {
+				t.Errorf("got status %d after %d attempts, want 200 after 2", resp.StatusCode, attempts)
+			}
+		})
+	}
+}
+
+func TestDoRequest_RetryAfterDeadline(t *testing.T) {
+	attempts := 0
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Body:       io.NopCloser(strings.NewReader("slow down")),
+			Header:     http.Header{"Retry-After": {"3600"}},
+		}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := Options{HTTPClient: client, MaxRetries: 3}.doRequest(ctx, http.MethodGet, "https://example.com/feed")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("doRequest() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("doRequest() waited %v for a Retry-After beyond the deadline", elapsed)
+	}
+	if attempts != 1 {
+		t.Errorf("made %d attempts, want 1", attempts)
+	}
+
+	// Without retries the 429 is returned as is
+	resp, err := Options{HTTPClient: client}.doRequest(context.Background(), http.MethodGet, "https://example.com/feed")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status = %d, want 429", resp.StatusCode)
+	}
+}
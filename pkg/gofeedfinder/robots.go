@@ -0,0 +1,148 @@
+package gofeedfinder
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxRobotsSize limits how much of a robots.txt file we'll read (512KB, per RFC 9309)
+const maxRobotsSize = 512 * 1024
+
+// robotsRule is a single Allow or Disallow line from robots.txt.
+type robotsRule struct {
+	pattern string
+	allow   bool
+}
+
+// robotsRules holds the robots.txt rules that apply to our User-Agent.
+// A nil *robotsRules allows everything.
+type robotsRules struct {
+	rules []robotsRule
+}
+
+// fetchRobotsRules fetches and parses robots.txt from the site at scheme://host.
+// A missing or unreadable robots.txt allows everything.
+func fetchRobotsRules(ctx context.Context, scheme, host string, opts Options) *robotsRules {
+	resp, err := opts.doRequest(ctx, http.MethodGet, scheme+"://"+host+"/robots.txt")
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil
+	}
+
+	return parseRobots(io.LimitReader(resp.Body, maxRobotsSize), opts.userAgent())
+}
+
+// parseRobots parses robots.txt content and keeps the rules from the groups that match
+// the product token of userAgent, or from the "*" groups if none match.
+func parseRobots(r io.Reader, userAgent string) *robotsRules {
+	token := strings.ToLower(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+
+	var matched, wildcard []robotsRule
+	var groupAgents []string
+	inRules := false
+	foundMatch := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			// A user-agent line after rules starts a new group
+			if inRules {
+				groupAgents = nil
+				inRules = false
+			}
+			groupAgents = append(groupAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				continue
+			}
+			rule := robotsRule{pattern: value, allow: key == "allow"}
+			for _, agent := range groupAgents {
+				if agent == token {
+					matched = append(matched, rule)
+					foundMatch = true
+					break
+				}
+				if agent == "*" {
+					wildcard = append(wildcard, rule)
+					break
+				}
+			}
+		}
+	}
+
+	if foundMatch {
+		return &robotsRules{rules: matched}
+	}
+	return &robotsRules{rules: wildcard}
+}
+
+// allowed reports whether path may be fetched. The longest matching rule wins,
+// with Allow winning ties.
+func (r *robotsRules) allowed(path string) bool {
+	if r == nil {
+		return true
+	}
+
+	allow := true
+	longest := -1
+	for _, rule := range r.rules {
+		if !robotsPatternMatches(rule.pattern, path) {
+			continue
+		}
+		if len(rule.pattern) > longest || (len(rule.pattern) == longest && rule.allow) {
+			longest = len(rule.pattern)
+			allow = rule.allow
+		}
+	}
+	return allow
+}
+
+// robotsPatternMatches reports whether a robots.txt path pattern matches path.
+// Patterns are prefix matches supporting the "*" wildcard and a trailing "$" anchor.
+func robotsPatternMatches(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	rest := path[len(parts[0]):]
+	for _, part := range parts[1:] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+
+	if anchored && rest != "" {
+		// The last literal must end the path; retry with it aligned to the end
+		last := parts[len(parts)-1]
+		return len(parts) > 1 && strings.HasSuffix(path, last)
+	}
+	return true
+}
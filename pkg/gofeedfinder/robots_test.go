@@ -0,0 +1,91 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseRobots(t *testing.T) {
+	robotsTxt := `# Example robots.txt
+User-agent: *
+Disallow: /feed
+Disallow: /private/
+Allow: /private/feed.xml
+
+User-agent: gofeedfinder
+User-agent: otherbot
+Disallow: /rss
+Disallow: /*.atom$
+`
+
+	tests := []struct {
+		name      string
+		userAgent string
+		path      string
+		expected  bool
+	}{
+		{name: "Wildcard group disallows", userAgent: "somebot/1.0", path: "/feed", expected: false},
+		{name: "Wildcard group prefix match", userAgent: "somebot/1.0", path: "/feed.xml", expected: false},
+		{name: "Longer allow wins", userAgent: "somebot/1.0", path: "/private/feed.xml", expected: true},
+		{name: "Disallowed directory", userAgent: "somebot/1.0", path: "/private/rss", expected: false},
+		{name: "Unlisted path", userAgent: "somebot/1.0", path: "/atom.xml", expected: true},
+		{name: "Specific group replaces wildcard", userAgent: "gofeedfinder/1.0", path: "/feed", expected: true},
+		{name: "Specific group disallows", userAgent: "gofeedfinder/1.0", path: "/rss", expected: false},
+		{name: "Wildcard and anchor", userAgent: "GoFeedFinder/2.0", path: "/posts.atom", expected: false},
+		{name: "Anchor does not match longer path", userAgent: "gofeedfinder/1.0", path: "/posts.atom.bak", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := parseRobots(strings.NewReader(robotsTxt), tt.userAgent)
+			if got := rules.allowed(tt.path); got != tt.expected {
+				t.Errorf("allowed(%q) for %q = %v, want %v", tt.path, tt.userAgent, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScanCommonFeedPaths_RespectRobots(t *testing.T) {
+	var mu sync.Mutex
+	requested := make(map[string]bool)
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requested[req.URL.Path] = true
+		mu.Unlock()
+
+		switch req.URL.Path {
+		case "/robots.txt":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("User-agent: *\nDisallow: /feed\n")),
+				Header:     make(http.Header),
+			}, nil
+		case "/feed", "/rss":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     map[string][]string{"Content-Type": {"application/rss+xml"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	feeds, err := scanCommonFeedPaths("https://example.com", Options{HTTPClient: client, RespectRobots: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requested["/feed"] {
+		t.Errorf("expected /feed to be skipped")
+	}
+	if len(feeds) != 1 || feeds[0].URL != "https://example.com/rss" {
+		t.Errorf("expected only https://example.com/rss, got %+v", feeds)
+	}
+}
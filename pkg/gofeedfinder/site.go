@@ -0,0 +1,48 @@
+package gofeedfinder
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Site describes a web page along with the feeds discovered on it.
+type Site struct {
+	PageTitle   string `json:"page_title"`  // The page's <title>
+	Description string `json:"description"` // The page's <meta name="description">, if any
+	Feeds       []Feed `json:"feeds"`
+}
+
+// DiscoverSite discovers feeds on the page at url like FindFeedsWithOptions, and also
+// returns the page's title and description from its head. They're left empty when the
+// page isn't fetched, as when a site-specific feed is found first.
+func DiscoverSite(url string, opts Options) (*Site, error) {
+	site := &Site{}
+	err := findFeedsWithCallback(context.Background(), url, opts, func(feed Feed) {
+		site.Feeds = append(site.Feeds, feed)
+	}, discoveryHooks{onPage: func(page *htmlPage) {
+		site.PageTitle, site.Description = extractPageMeta(page.document())
+	}})
+	if err != nil {
+		return nil, err
+	}
+	sortFeeds(site.Feeds, opts.SortBy)
+	return site, nil
+}
+
+// extractPageMeta returns the text of the first <title> in doc and the content of its
+// <meta name="description">.
+func extractPageMeta(doc *goquery.Document) (title, description string) {
+	title = strings.TrimSpace(doc.Find("title").First().Text())
+	doc.Find("meta[name][content]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		name, _ := s.Attr("name")
+		if !strings.EqualFold(strings.TrimSpace(name), "description") {
+			return true
+		}
+		content, _ := s.Attr("content")
+		description = strings.TrimSpace(content)
+		return false
+	})
+	return title, description
+}
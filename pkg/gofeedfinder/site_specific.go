@@ -0,0 +1,64 @@
+package gofeedfinder
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// siteMatcher maps pages on a known site to the feed URL that site exposes for them.
+type siteMatcher struct {
+	hosts   []string                        // Hostnames the matcher applies to
+	feedURL func(u *url.URL) (string, bool) // Builds the feed URL for a page, if it has one
+}
+
+// siteMatchers is the registry of known sites that expose feeds at predictable URLs.
+var siteMatchers = []siteMatcher{
+	{
+		hosts:   []string{"reddit.com", "www.reddit.com", "old.reddit.com"},
+		feedURL: redditFeedURL,
+	},
+}
+
+// redditFeedURL builds the .rss feed URL for subreddit and user pages,
+// e.g. /r/golang -> https://www.reddit.com/r/golang/.rss.
+func redditFeedURL(u *url.URL) (string, bool) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(segments) < 2 || segments[1] == "" {
+		return "", false
+	}
+
+	switch segments[0] {
+	case "r":
+		return "https://www.reddit.com/r/" + segments[1] + "/.rss", true
+	case "user", "u":
+		return "https://www.reddit.com/user/" + segments[1] + "/.rss", true
+	}
+	return "", false
+}
+
+// findSiteSpecificFeeds returns validated feeds for pages on sites in the siteMatchers registry.
+func findSiteSpecificFeeds(ctx context.Context, pageURL string, opts Options) []Feed {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+
+	var feeds []Feed
+	for _, matcher := range siteMatchers {
+		if !slices.Contains(matcher.hosts, host) {
+			continue
+		}
+		candidate, ok := matcher.feedURL(u)
+		if !ok {
+			continue
+		}
+		if feed, err := checkFeedURL(ctx, candidate, opts); err == nil && feed != nil {
+			feed.Source = SourceSite
+			feeds = append(feeds, *feed)
+		}
+	}
+	return feeds
+}
@@ -0,0 +1,81 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindFeedsWithOptions_SiteSpecific(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/.rss") {
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     map[string][]string{"Content-Type": {"application/atom+xml; charset=UTF-8"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 403,
+			Body:       io.NopCloser(strings.NewReader("Blocked")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	tests := []struct {
+		name     string
+		url      string
+		expected []Feed
+	}{
+		{
+			name:     "Subreddit",
+			url:      "https://www.reddit.com/r/golang/",
+			expected: []Feed{{URL: "https://www.reddit.com/r/golang/.rss", Title: "", Type: "atom", Source: SourceSite}},
+		},
+		{
+			name:     "User profile",
+			url:      "https://old.reddit.com/user/spez/comments",
+			expected: []Feed{{URL: "https://www.reddit.com/user/spez/.rss", Title: "", Type: "atom", Source: SourceSite}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			feeds, err := FindFeedsWithOptions(tt.url, Options{HTTPClient: client, EnableSiteSpecific: true})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(feeds, tt.expected) {
+				t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedditFeedURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+		ok       bool
+	}{
+		{name: "Subreddit", url: "https://reddit.com/r/golang", expected: "https://www.reddit.com/r/golang/.rss", ok: true},
+		{name: "Short user path", url: "https://www.reddit.com/u/spez", expected: "https://www.reddit.com/user/spez/.rss", ok: true},
+		{name: "Front page", url: "https://www.reddit.com/", ok: false},
+		{name: "Other section", url: "https://www.reddit.com/settings/profile", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _ := url.Parse(tt.url)
+			result, ok := redditFeedURL(u)
+			if ok != tt.ok || result != tt.expected {
+				t.Errorf("redditFeedURL(%q) = %q, %v, want %q, %v", tt.url, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractPageMeta(t *testing.T) {
+	tests := []struct {
+		name        string
+		html        string
+		title       string
+		description string
+	}{
+		{
+			name:        "Title and description",
+			html:        `<html><head><title> Example Blog </title><meta name="Description" content="Posts about Go"></head></html>`,
+			title:       "Example Blog",
+			description: "Posts about Go",
+		},
+		{
+			name:  "Title only",
+			html:  `<html><head><title>Example Blog</title><meta name="keywords" content="go"></head></html>`,
+			title: "Example Blog",
+		},
+		{
+			name: "Empty head",
+			html: `<html><head></head></html>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, description := extractPageMeta(newHTMLPage(tt.html).document())
+			if title != tt.title || description != tt.description {
+				t.Errorf("extractPageMeta() = %q, %q, want %q, %q", title, description, tt.title, tt.description)
+			}
+		})
+	}
+}
+
+func TestDiscoverSite(t *testing.T) {
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(strings.NewReader(`<html><head>
+				<title>Example Blog</title>
+				<meta name="description" content="Posts about Go">
+				<link rel="alternate" type="application/rss+xml" href="/feed.xml" title="Posts">
+			</head><body></body></html>`)),
+			Header: map[string][]string{"Content-Type": {"text/html"}},
+		}, nil
+	})
+
+	site, err := DiscoverSite("https://example.com", Options{HTTPClient: client})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Site{
+		PageTitle:   "Example Blog",
+		Description: "Posts about Go",
+		Feeds:       []Feed{{URL: "https://example.com/feed.xml", Title: "Posts", Type: "rss", Source: SourceLink}},
+	}
+	if !cmp.Equal(site, expected) {
+		t.Errorf("DiscoverSite() = %+v, want %+v", site, expected)
+	}
+}
@@ -0,0 +1,176 @@
+package gofeedfinder
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/markgx/gofeedfinder/pkg/gofeedfinder/internal"
+)
+
+// MaxSitemapURLs is how many feed-like URLs from a site's sitemaps UseSitemap checks.
+const MaxSitemapURLs = 20
+
+// maxSitemapFetches limits how many sitemap documents UseSitemap reads, including the
+// sitemaps listed by a sitemap index.
+const maxSitemapFetches = 5
+
+// findSitemapFeeds checks the feed-like URLs listed in the sitemaps of baseURL's host:
+// those declared in robots.txt, or /sitemap.xml if it declares none. Sitemap indexes are
+// followed, up to maxSitemapFetches documents, and at most MaxSitemapURLs URLs are checked.
+func findSitemapFeeds(ctx context.Context, baseURL string, opts Options) []Feed {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil || parsedURL.Host == "" {
+		return nil
+	}
+	root := parsedURL.Scheme + "://" + parsedURL.Host
+
+	queue := fetchRobotsSitemaps(ctx, root, opts)
+	if len(queue) == 0 {
+		queue = []string{root + "/sitemap.xml"}
+	}
+
+	var candidates []string
+	for fetched := 0; len(queue) > 0 && fetched < maxSitemapFetches && len(candidates) < MaxSitemapURLs; fetched++ {
+		sitemapURL := queue[0]
+		queue = queue[1:]
+
+		locs, isIndex := fetchSitemap(ctx, sitemapURL, opts)
+		if isIndex {
+			queue = append(queue, locs...)
+			continue
+		}
+		for _, loc := range locs {
+			if len(candidates) == MaxSitemapURLs {
+				break
+			}
+			if looksLikeFeedURL(loc) && !slices.Contains(candidates, loc) {
+				candidates = append(candidates, loc)
+			}
+		}
+	}
+
+	opts.logf("checking %d feed-like URLs from the sitemaps of %s", len(candidates), root)
+	var feeds []Feed
+	for _, feed := range checkFeedURLs(ctx, candidates, opts) {
+		if feed != nil {
+			feed.Source = SourceSitemap
+			feeds = append(feeds, *feed)
+		}
+	}
+	return feeds
+}
+
+// fetchRobotsSitemaps returns the sitemap URLs declared in the robots.txt of the site at
+// root, or nil if it has none or can't be fetched.
+func fetchRobotsSitemaps(ctx context.Context, root string, opts Options) []string {
+	resp, err := opts.doRequest(ctx, http.MethodGet, root+"/robots.txt")
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil
+	}
+	return parseRobotsSitemaps(io.LimitReader(resp.Body, maxRobotsSize), root)
+}
+
+// parseRobotsSitemaps returns the URLs of the Sitemap lines in robots.txt content,
+// resolved against root. Sitemap lines apply to the whole file, whatever group they're in.
+func parseRobotsSitemaps(r io.Reader, root string) []string {
+	var sitemaps []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "sitemap") {
+			continue
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			sitemaps = append(sitemaps, internal.ResolveFeedURL(value, root))
+		}
+	}
+	return sitemaps
+}
+
+// fetchSitemap fetches the sitemap at sitemapURL and returns its <loc> URLs, and whether
+// it's a sitemap index listing other sitemaps rather than pages. A sitemap that can't be
+// fetched has no URLs.
+func fetchSitemap(ctx context.Context, sitemapURL string, opts Options) ([]string, bool) {
+	resp, err := opts.doRequest(ctx, http.MethodGet, sitemapURL)
+	if err != nil {
+		opts.logf("sitemap %s failed: %v", sitemapURL, err)
+		return nil, false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		opts.logf("sitemap %s failed: %v", sitemapURL, newHTTPStatusError(resp, http.MethodGet))
+		return nil, false
+	}
+	return parseSitemap(resp.Body, finalURL(resp, sitemapURL))
+}
+
+// parseSitemap reads the <loc> URLs from a sitemap or sitemap index in r, resolved against
+// sitemapURL, and reports whether the document is a sitemap index. Reading stops at the
+// first malformed token, keeping the URLs read so far.
+func parseSitemap(r io.Reader, sitemapURL string) (locs []string, isIndex bool) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	root := true
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return locs, isIndex
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			isIndex = start.Name.Local == "sitemapindex"
+			root = false
+		}
+		if start.Name.Local != "loc" {
+			continue
+		}
+		var loc string
+		if err := dec.DecodeElement(&loc, &start); err != nil {
+			return locs, isIndex
+		}
+		if loc = strings.TrimSpace(loc); loc != "" {
+			locs = append(locs, internal.ResolveFeedURL(loc, sitemapURL))
+		}
+	}
+}
+
+// looksLikeFeedURL reports whether a URL from a sitemap looks like a feed: its file
+// extension is a feed one (such as .rss), its last path segment is a feed keyword (such
+// as /feed/ or feed.json), or it has a WordPress-style ?feed= query.
+func looksLikeFeedURL(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if parsedURL.Query().Has("feed") {
+		return true
+	}
+
+	name := path.Base(strings.ToLower(strings.TrimSuffix(parsedURL.Path, "/")))
+	ext := path.Ext(name)
+	return slices.Contains(anchorFeedExtensions, ext) || slices.Contains(anchorFeedKeywords, strings.TrimSuffix(name, ext))
+}
@@ -0,0 +1,112 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindFeedsWithOptions_UseSitemap(t *testing.T) {
+	urlset := `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://example.com/</loc></url>
+  <url><loc>https://example.com/about/</loc></url>
+  <url><loc>https://example.com/news/feed/</loc></url>
+  <url><loc>https://example.com/old.rss</loc></url>
+</urlset>`
+	index := `<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://example.com/page-sitemap.xml</loc></sitemap>
+</sitemapindex>`
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "Default sitemap location",
+			files: map[string]string{"/sitemap.xml": urlset},
+		},
+		{
+			name: "Sitemap index declared in robots.txt",
+			files: map[string]string{
+				"/robots.txt":        "User-agent: *\nDisallow: /admin\n\nSitemap: /sitemap_index.xml\n",
+				"/sitemap_index.xml": index,
+				"/page-sitemap.xml":  urlset,
+				"/sitemap.xml":       "<urlset></urlset>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []string
+			client := testClient(func(req *http.Request) (*http.Response, error) {
+				requested = append(requested, req.Method+" "+req.URL.Path)
+				switch {
+				case req.URL.Path == "":
+					return &http.Response{
+						StatusCode: 200,
+						Body:       io.NopCloser(strings.NewReader(`<html><head><title>Sparse</title></head></html>`)),
+						Header:     make(http.Header),
+					}, nil
+				case req.URL.Path == "/news/feed/":
+					return &http.Response{
+						StatusCode: 200,
+						Body:       http.NoBody,
+						Header:     map[string][]string{"Content-Type": {"application/rss+xml"}},
+					}, nil
+				case tt.files[req.URL.Path] != "":
+					return &http.Response{
+						StatusCode: 200,
+						Body:       io.NopCloser(strings.NewReader(tt.files[req.URL.Path])),
+						Header:     map[string][]string{"Content-Type": {"application/xml"}},
+					}, nil
+				}
+				return &http.Response{StatusCode: 404, Body: http.NoBody, Header: make(http.Header)}, nil
+			})
+
+			feeds, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client, UseSitemap: true, MaxConcurrency: 1})
+			if err != nil {
+				t.Fatalf("unexpected error: %v (requests: %v)", err, requested)
+			}
+			expected := []Feed{{URL: "https://example.com/news/feed/", Type: "rss", Source: SourceSitemap}}
+			if !cmp.Equal(feeds, expected) {
+				t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+			}
+			// Only the feed-like URLs are checked, not every page listed
+			for _, r := range requested {
+				if r == "HEAD /about/" {
+					t.Errorf("checked a page that doesn't look like a feed: %v", requested)
+				}
+			}
+		})
+	}
+}
+
+func TestLooksLikeFeedURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{url: "https://example.com/feed/", expected: true},
+		{url: "https://example.com/blog/rss", expected: true},
+		{url: "https://example.com/feed.json", expected: true},
+		{url: "https://example.com/updates.atom", expected: true},
+		{url: "https://example.com/?feed=rss2", expected: true},
+		{url: "https://example.com/", expected: false},
+		{url: "https://example.com/feeding-tips/", expected: false},
+		{url: "https://example.com/posts/hello-world", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := looksLikeFeedURL(tt.url); got != tt.expected {
+				t.Errorf("looksLikeFeedURL(%q) = %v, want %v", tt.url, got, tt.expected)
+			}
+		})
+	}
+}
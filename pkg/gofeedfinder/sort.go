@@ -0,0 +1,38 @@
+package gofeedfinder
+
+import (
+	"slices"
+	"strings"
+)
+
+// Feed orderings for Options.SortBy
+const (
+	// SortNone keeps feeds in the order they were discovered. This is the default.
+	SortNone = "none"
+	// SortURL orders feeds by URL.
+	SortURL = "url"
+	// SortType orders feeds by type ("atom", "json", "rss", ...).
+	SortType = "type"
+	// SortTitle orders feeds by title, ignoring case.
+	SortTitle = "title"
+)
+
+// sortFeeds orders feeds in place by the key named by sortBy. The sort is stable, so
+// feeds with equal keys stay in discovery order. An empty or unknown sortBy leaves the
+// order unchanged.
+func sortFeeds(feeds []Feed, sortBy string) {
+	var key func(Feed) string
+	switch sortBy {
+	case SortURL:
+		key = func(f Feed) string { return f.URL }
+	case SortType:
+		key = func(f Feed) string { return f.Type }
+	case SortTitle:
+		key = func(f Feed) string { return strings.ToLower(f.Title) }
+	default:
+		return
+	}
+	slices.SortStableFunc(feeds, func(a, b Feed) int {
+		return strings.Compare(key(a), key(b))
+	})
+}
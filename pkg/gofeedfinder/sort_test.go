@@ -0,0 +1,47 @@
+package gofeedfinder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFindFeedsFromReader_SortBy(t *testing.T) {
+	html := `<html><head>
+		<link rel="alternate" type="application/rss+xml" href="/b.xml" title="beta">
+		<link rel="alternate" type="application/atom+xml" href="/c.xml" title="Alpha">
+		<link rel="alternate" type="application/rss+xml" href="/a.xml" title="Gamma">
+		<link rel="alternate" type="application/feed+json" href="/d.json" title="alpha">
+		</head></html>`
+
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: "", expected: []string{"/b.xml", "/c.xml", "/a.xml", "/d.json"}},
+		{sortBy: SortNone, expected: []string{"/b.xml", "/c.xml", "/a.xml", "/d.json"}},
+		{sortBy: SortURL, expected: []string{"/a.xml", "/b.xml", "/c.xml", "/d.json"}},
+		// Equal keys keep discovery order: /b.xml was found before /a.xml
+		{sortBy: SortType, expected: []string{"/c.xml", "/d.json", "/b.xml", "/a.xml"}},
+		// Titles compare without case, and "Alpha" was found before "alpha"
+		{sortBy: SortTitle, expected: []string{"/c.xml", "/d.json", "/b.xml", "/a.xml"}},
+		{sortBy: "unknown", expected: []string{"/b.xml", "/c.xml", "/a.xml", "/d.json"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			feeds, err := FindFeedsFromReader(strings.NewReader(html), "https://example.com", Options{SortBy: tt.sortBy})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var paths []string
+			for _, feed := range feeds {
+				paths = append(paths, strings.TrimPrefix(feed.URL, "https://example.com"))
+			}
+			if !cmp.Equal(paths, tt.expected) {
+				t.Errorf("SortBy %q gave %v, want %v", tt.sortBy, paths, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package gofeedfinder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// feedTitle returns the feed-level title of the RSS, Atom, or JSON Feed document in r,
+// or "" if it has none. Item and entry titles are ignored.
+func feedTitle(r io.Reader) string {
+	br := bufio.NewReader(r)
+	peek, _ := br.Peek(512)
+	if bytes.HasPrefix(bytes.TrimSpace(peek), []byte("{")) {
+		return jsonFeedTitle(br)
+	}
+	return xmlFeedTitle(br)
+}
+
+// xmlFeedTitle returns the text of the <title> that is a child of an Atom <feed> or an
+// RSS <channel>, stopping at the first item or entry.
+func xmlFeedTitle(r io.Reader) string {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	var stack []string
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := strings.ToLower(t.Name.Local)
+			switch name {
+			case "item", "entry":
+				return ""
+			case "title":
+				if len(stack) > 0 && (stack[len(stack)-1] == "feed" || stack[len(stack)-1] == "channel") {
+					var title string
+					if err := dec.DecodeElement(&title, &t); err != nil {
+						return ""
+					}
+					return strings.TrimSpace(title)
+				}
+			}
+			stack = append(stack, name)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// jsonFeedTitle returns the top-level "title" of a JSON Feed document.
+func jsonFeedTitle(r io.Reader) string {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return ""
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if key, ok := tok.(string); ok && key == "title" {
+			var title string
+			if err := dec.Decode(&title); err != nil {
+				return ""
+			}
+			return strings.TrimSpace(title)
+		}
+		if err := skipJSONValue(dec); err != nil {
+			return ""
+		}
+	}
+	return ""
+}
+
+// fetchFeedTitle GETs the feed at url and returns its feed-level title.
+func fetchFeedTitle(ctx context.Context, url string, opts Options) (string, error) {
+	resp, err := opts.doFeedRequest(ctx, http.MethodGet, url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", newHTTPStatusError(resp, http.MethodGet)
+	}
+	return feedTitle(io.LimitReader(resp.Body, MaxHeadSize)), nil
+}
+
+// enrichTitles fills in the Title of feeds that have none from the feed documents themselves,
+// fetching up to opts.MaxConcurrency feeds at a time. Feeds that can't be fetched keep
+// their empty title.
+func enrichTitles(ctx context.Context, feeds []Feed, opts Options) {
+	maxConcurrency := opts.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultMaxConcurrency
+	}
+
+	semaphore := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range feeds {
+		if feeds[i].Title != "" {
+			continue
+		}
+		wg.Add(1)
+		go func(feed *Feed) {
+			defer wg.Done()
+			semaphore <- struct{}{}        // Acquire semaphore
+			defer func() { <-semaphore }() // Release semaphore
+
+			title, err := fetchFeedTitle(ctx, feed.URL, opts)
+			if err != nil {
+				opts.logf("couldn't fetch title for %s: %v", feed.URL, err)
+				return
+			}
+			feed.Title = title
+		}(&feeds[i])
+	}
+	wg.Wait()
+}
@@ -0,0 +1,94 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFeedTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "RSS channel title",
+			content:  `<?xml version="1.0"?><rss version="2.0"><channel><title> Example News </title><item><title>Story</title></item></channel></rss>`,
+			expected: "Example News",
+		},
+		{
+			name:     "RSS item before channel title",
+			content:  `<rss version="2.0"><channel><item><title>Story</title></item><title>Late</title></channel></rss>`,
+			expected: "",
+		},
+		{
+			name:     "RDF channel title",
+			content:  `<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/"><channel><title>RDF Site</title></channel></rdf:RDF>`,
+			expected: "RDF Site",
+		},
+		{
+			name:     "Atom feed title with CDATA",
+			content:  `<feed xmlns="http://www.w3.org/2005/Atom"><author><name>A</name></author><title><![CDATA[Atom & Co]]></title><entry><title>Post</title></entry></feed>`,
+			expected: "Atom & Co",
+		},
+		{
+			name:     "JSON Feed title",
+			content:  ` {"version": "https://jsonfeed.org/version/1.1", "items": [{"title": "Post"}], "title": "JSON Site"}`,
+			expected: "JSON Site",
+		},
+		{
+			name:     "No title",
+			content:  `<rss version="2.0"><channel><link>https://example.com</link></channel></rss>`,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := feedTitle(strings.NewReader(tt.content)); got != tt.expected {
+				t.Errorf("feedTitle() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindFeedsWithOptions_EnrichTitles(t *testing.T) {
+	html := `<html><head>
+		<link rel="alternate" type="application/rss+xml" href="/rss.xml">
+		<link rel="alternate" type="application/atom+xml" href="/atom.xml" title="Linked Title">
+		</head><body></body></html>`
+	var requested []string
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		body := html
+		if req.URL.Path == "/rss.xml" {
+			body = `<?xml version="1.0"?><rss version="2.0"><channel><title>Feed Body Title</title></channel></rss>`
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	feeds, err := FindFeedsWithOptions("https://example.com", Options{HTTPClient: client, EnrichTitles: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Feed{
+		{URL: "https://example.com/rss.xml", Title: "Feed Body Title", Type: "rss", Source: SourceLink},
+		{URL: "https://example.com/atom.xml", Title: "Linked Title", Type: "atom", Source: SourceLink},
+	}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+
+	// Only the titleless feed is fetched
+	if !cmp.Equal(requested, []string{"", "/rss.xml"}) {
+		t.Errorf("requested paths = %v, want the page and /rss.xml", requested)
+	}
+}
@@ -0,0 +1,94 @@
+package gofeedfinder
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// wordPressFeedPaths are the feed URLs every WordPress site exposes, in priority order.
+// They're scanned ahead of commonFeedPaths when the site is WordPress.
+var wordPressFeedPaths = []string{
+	"/feed/",
+	"/?feed=rss2",
+	"/?feed=atom",
+	"/comments/feed/",
+}
+
+// wordPressGeneratorPattern matches a <meta name="generator" content="WordPress ..."> tag
+var wordPressGeneratorPattern = regexp.MustCompile(`(?i)<meta\b[^>]*\bcontent\s*=\s*["']?WordPress\b[^>]*>`)
+
+// IsWordPress reports whether html looks like a page served by WordPress, either from its
+// generator <meta> tag or from references to wp-content assets.
+func IsWordPress(html string) bool {
+	if strings.Contains(html, "/wp-content/") || strings.Contains(html, "/wp-includes/") {
+		return true
+	}
+	for _, tag := range wordPressGeneratorPattern.FindAllString(html, -1) {
+		if strings.Contains(strings.ToLower(tag), "generator") {
+			return true
+		}
+	}
+	return false
+}
+
+// scanPaths returns the paths a common path scan checks, in priority order: CustomFeedPaths,
+// then the WordPress paths if WordPress is set, then commonFeedPaths.
+func (o Options) scanPaths() []string {
+	if len(o.CustomFeedPaths) == 0 && !o.WordPress {
+		return commonFeedPaths
+	}
+
+	var paths []string
+	for _, p := range o.CustomFeedPaths {
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		if !strings.HasPrefix(p, "/") {
+			p = "/" + p
+		}
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	if o.WordPress {
+		paths = append(paths, wordPressFeedPaths...)
+	}
+	for _, p := range commonFeedPaths {
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// scanPathsFor returns the paths to check when scanning base: scanPaths, preceded by the
+// same paths under base's directory when ScanRelativeToPath is set and base isn't at the root.
+func (o Options) scanPathsFor(base *url.URL) []string {
+	paths := o.scanPaths()
+	dir := o.scanDir(base)
+	if dir == "/" {
+		return paths
+	}
+
+	relative := make([]string, 0, 2*len(paths))
+	for _, p := range paths {
+		relative = append(relative, dir+strings.TrimPrefix(p, "/"))
+	}
+	return append(relative, paths...)
+}
+
+// scanDir returns the directory of base that relative scan paths are joined onto, or
+// "/" when ScanRelativeToPath is unset.
+func (o Options) scanDir(base *url.URL) string {
+	if !o.ScanRelativeToPath {
+		return "/"
+	}
+	p := base.EscapedPath()
+	dir := p[:strings.LastIndex(p, "/")+1]
+	if dir == "" {
+		return "/"
+	}
+	return dir
+}
@@ -0,0 +1,103 @@
+package gofeedfinder
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestIsWordPress(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected bool
+	}{
+		{
+			name:     "Generator meta tag",
+			html:     `<html><head><meta name="generator" content="WordPress 6.4.2"></head></html>`,
+			expected: true,
+		},
+		{
+			name:     "Generator meta tag with content first",
+			html:     `<html><head><meta content="WordPress 6.4.2" name="generator" /></head></html>`,
+			expected: true,
+		},
+		{
+			name:     "wp-content assets",
+			html:     `<html><head><link rel="stylesheet" href="https://example.com/wp-content/themes/twentytwenty/style.css"></head></html>`,
+			expected: true,
+		},
+		{
+			name:     "Other generator",
+			html:     `<html><head><meta name="generator" content="Hugo 0.120.0"></head></html>`,
+			expected: false,
+		},
+		{
+			name:     "WordPress mentioned in a description",
+			html:     `<html><head><meta name="description" content="WordPress tips and tricks"></head></html>`,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsWordPress(tt.html); got != tt.expected {
+				t.Errorf("IsWordPress() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFindFeedsWithOptions_WordPress(t *testing.T) {
+	html := `<html><head><meta name="generator" content="WordPress 6.4.2"><title>Blog</title></head><body></body></html>`
+	client := testClient(func(req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(html)),
+				Header:     map[string][]string{"Content-Type": {"text/html"}},
+			}, nil
+		case "/feed/":
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(strings.NewReader(`<?xml version="1.0"?><rss version="2.0"></rss>`)),
+				Header:     map[string][]string{"Content-Type": {"application/rss+xml; charset=UTF-8"}},
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(strings.NewReader("Not Found")),
+			Header:     make(http.Header),
+		}, nil
+	})
+
+	feeds, err := FindFeedsWithOptions("https://example.com/", Options{HTTPClient: client, ScanCommonPaths: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Feed{{URL: "https://example.com/feed/", Title: "", Type: "rss", Source: SourceScan}}
+	if !cmp.Equal(feeds, expected) {
+		t.Errorf("FindFeedsWithOptions() = %+v, want %+v", feeds, expected)
+	}
+}
+
+func TestScanPaths(t *testing.T) {
+	if got := (Options{}).scanPaths(); !cmp.Equal(got, commonFeedPaths) {
+		t.Errorf("scanPaths() = %v, want commonFeedPaths", got)
+	}
+
+	got := (Options{WordPress: true}).scanPaths()
+	if !cmp.Equal(got[:len(wordPressFeedPaths)], wordPressFeedPaths) || len(got) != len(wordPressFeedPaths)+len(commonFeedPaths) {
+		t.Errorf("scanPaths() with WordPress = %v, want WordPress paths followed by commonFeedPaths", got)
+	}
+
+	got = (Options{CustomFeedPaths: []string{"blog/rss", " /custom.xml ", "/feed", ""}}).scanPaths()
+	expected := append([]string{"/blog/rss", "/custom.xml", "/feed"}, commonFeedPaths[1:]...)
+	if !cmp.Equal(got, expected) {
+		t.Errorf("scanPaths() with CustomFeedPaths = %v, want %v", got, expected)
+	}
+}